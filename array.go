@@ -9,6 +9,12 @@ import (
 type Array[T any] struct {
 	data    []T
 	iterIdx int
+	frozen  bool
+	// Set when the backing slice may be shared with a view
+	shared bool
+	// Array the view was created from, cleared once the view stops
+	// sharing its elements
+	parent *Array[T]
 }
 
 type ArrayEntry[T any] struct {
@@ -20,28 +26,76 @@ func NewArray[T any](data []T) *Array[T] {
 	return &Array[T]{data: data, iterIdx: 0}
 }
 
+// Makes the array read-only, any later call to a method modifying
+// the array panics with ErrFrozen
+func (a *Array[T]) Freeze() *Array[T] {
+	a.frozen = true
+	return a
+}
+
+// Returns true if the array has been frozen, or is a view of a
+// frozen array
+func (a *Array[T]) IsFrozen() bool {
+	return a.frozen || (a.parent != nil && a.parent.IsFrozen())
+}
+
+func (a *Array[T]) checkFrozen() {
+	if a.IsFrozen() {
+		panic(ErrFrozen)
+	}
+}
+
+// Returns a read-only view of the array. The view does not copy the
+// elements, changes made to the array are visible through it.
+func (a *Array[T]) ReadOnly() *ReadOnlyArray[T] {
+	return &ReadOnlyArray[T]{array: a}
+}
+
 // Adds new elements to the end of the array
 func (a *Array[T]) Push(data ...T) *Array[T] {
-	a.data = append(a.data, data...)
+	a.checkFrozen()
+	a.append(data)
 	return a
 }
 
+func (a *Array[T]) append(data []T) {
+	if len(a.data)+len(data) > cap(a.data) {
+		a.shared = false
+		a.parent = nil
+	}
+	a.data = append(a.data, data...)
+}
+
+// Replaces the backing slice with a newly allocated one
+func (a *Array[T]) replaceData(data []T) {
+	a.data = data
+	a.shared = false
+	a.parent = nil
+}
+
 // Adds new elements to the beginning of the array
 func (a *Array[T]) Unshift(data ...T) *Array[T] {
-	a.data = append(data, a.data...)
+	a.checkFrozen()
+	a.replaceData(slices.Concat(data, a.data))
 	return a
 }
 
 // Removes the last element from an array and returns that element
 func (a *Array[T]) Pop() T {
+	a.checkFrozen()
 	lastIdx := len(a.data) - 1
 	data := a.data[lastIdx]
 	a.data = a.data[:lastIdx]
+	if a.shared {
+		// Prevents a later Push from overwriting the shared element
+		a.data = a.data[:lastIdx:lastIdx]
+	}
 	return data
 }
 
 // Removes the first element from an array and returns that element
 func (a *Array[T]) Shift() T {
+	a.checkFrozen()
 	data := a.data[0]
 	a.data = a.data[1:]
 	return data
@@ -58,6 +112,7 @@ func (a *Array[T]) At(idx int) T {
 
 // Changes the value at the specified index
 func (a *Array[T]) Set(idx int, data T) *Array[T] {
+	a.checkFrozen()
 	a.data[idx] = data
 	return a
 }
@@ -65,7 +120,8 @@ func (a *Array[T]) Set(idx int, data T) *Array[T] {
 // Inserts new elements at the specified index, shifting the
 // elements after the index
 func (a *Array[T]) Insert(at int, data ...T) *Array[T] {
-	a.data = append(a.data[:at], append(data, a.data[at:]...)...)
+	a.checkFrozen()
+	a.replaceData(slices.Concat(a.data[:at], data, a.data[at:]))
 	return a
 }
 
@@ -76,33 +132,51 @@ func (a *Array[T]) Length() int {
 
 // Returns a shallow copy of a portion of an array
 func (a *Array[T]) Slice(start, end int) *Array[T] {
-	return NewArray[T](a.data[start:end])
+	return NewArray(slices.Clone(a.data[start:end]))
+}
+
+// Returns an array sharing the elements of a portion of the array.
+// Elements changed in place, by Set, Reverse or sorting, are visible
+// in both arrays. Adding or removing elements never affects the other
+// array, after that the two arrays no longer share the affected
+// elements. The view is frozen while the array is, including when
+// the array is frozen after the view was created.
+func (a *Array[T]) View(start, end int) *Array[T] {
+	view := NewArray(a.data[start:end:end])
+	view.parent = a
+	view.shared = true
+	a.shared = true
+	return view
 }
 
 // Removes {count} elements from an array starting from the given index
 // and returns the removed elements
 func (a *Array[T]) Splice(start, count int) *Array[T] {
+	a.checkFrozen()
 	s := slices.Clone(a.data[start : start+count])
-	a.data = append(a.data[:start], a.data[start+count:]...)
+	a.replaceData(slices.Concat(a.data[:start], a.data[start+count:]))
 	return NewArray[T](s)
 }
 
 // Removes {count} elements from an array starting from the given index,
 // inserts new elements in their place and returns the removed elements
 func (a *Array[T]) Replace(start, count int, data ...T) *Array[T] {
+	a.checkFrozen()
 	s := slices.Clone(a.data[start : start+count])
-	a.data = append(a.data[:start], append(data, a.data[start+count:]...)...)
+	a.replaceData(slices.Concat(a.data[:start], data, a.data[start+count:]))
 	return NewArray[T](s)
 }
 
 // Concatenates in place the elements of a provided array
 func (a *Array[T]) Concat(arr *Array[T]) *Array[T] {
-	a.data = append(a.data, arr.data...)
+	a.checkFrozen()
+	a.append(arr.data)
 	return a
 }
 
 // Reverses the array in place
 func (a *Array[T]) Reverse() *Array[T] {
+	a.checkFrozen()
 	slices.Reverse[[]T](a.data)
 	return a
 }
@@ -110,13 +184,14 @@ func (a *Array[T]) Reverse() *Array[T] {
 // Removes elements from the array that do not satisfy the
 // predicate
 func (a *Array[T]) Remove(predicate func(T, int) bool) *Array[T] {
+	a.checkFrozen()
 	var arr []T
 	for idx, v := range a.data {
 		if !predicate(v, idx) {
 			arr = append(arr, v)
 		}
 	}
-	a.data = arr
+	a.replaceData(arr)
 	return a
 }
 
@@ -189,6 +264,20 @@ func (a *Array[T]) Copy() *Array[T] {
 	return NewArray[T](arr)
 }
 
+// Creates a deep copy of the array, see DeepClone for how the
+// elements are copied
+func (a *Array[T]) DeepCopy(reflectStructs bool) *Array[T] {
+	return DeepClone(a, reflectStructs)
+}
+
+func (a *Array[T]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*Array[T])
+	a.data = make([]T, len(from.data))
+	for i, v := range from.data {
+		a.data[i] = cloneValue(s, v)
+	}
+}
+
 // Creates a new slice with the same elements as the array and returns it
 func (a *Array[T]) ToSlice() []T {
 	return a.Copy().data
@@ -196,11 +285,13 @@ func (a *Array[T]) ToSlice() []T {
 
 // Sorts the array in place
 func (a *Array[T]) SortWith(compare func(T, T) int) {
+	a.checkFrozen()
 	slices.SortFunc[[]T, T](a.data, compare)
 }
 
 // Sorts the array in place in reverse order
 func (a *Array[T]) SortWithReverse(compare func(T, T) int) {
+	a.checkFrozen()
 	slices.SortFunc[[]T, T](a.data, func(a, b T) int {
 		return compare(b, a)
 	})
@@ -237,6 +328,7 @@ func (a *Array[T]) Iter() func(func(T) bool) {
 // Sorts the array in place using the provided function to get the
 // comparable value
 func Sort[T any, C cmp.Ordered](array *Array[T], getComparable func(T) C) {
+	array.checkFrozen()
 	slices.SortFunc[[]T, T](array.data, func(a, b T) int {
 		return cmp.Compare(getComparable(a), getComparable(b))
 	})
@@ -245,6 +337,7 @@ func Sort[T any, C cmp.Ordered](array *Array[T], getComparable func(T) C) {
 // Sorts the array in place in reverse order using the provided
 // function to get the comparable value
 func SortReverse[T any, C cmp.Ordered](array *Array[T], getComparable func(T) C) {
+	array.checkFrozen()
 	slices.SortFunc[[]T, T](array.data, func(a, b T) int {
 		return cmp.Compare(getComparable(b), getComparable(a))
 	})
@@ -295,7 +388,8 @@ func LastIndexOf[T comparable](array *Array[T], elem T) int {
 
 // Removes consecutive duplicates from the array in place
 func Compact[T comparable](array *Array[T]) *Array[T] {
-	array.data = slices.Compact[[]T, T](array.data)
+	array.checkFrozen()
+	array.replaceData(slices.Compact(slices.Clone(array.data)))
 	return array
 }
 
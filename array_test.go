@@ -488,3 +488,143 @@ func TestArrayJoin(t *testing.T) {
 		Join(arr2, ","),
 	)
 }
+
+func TestArrayFreeze(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray([]int{3, 1, 2})
+	assert.False(arr.IsFrozen())
+
+	arr.Freeze()
+	assert.True(arr.IsFrozen())
+
+	mutations := map[string]func(){
+		"Push":            func() { arr.Push(4) },
+		"Unshift":         func() { arr.Unshift(0) },
+		"Pop":             func() { arr.Pop() },
+		"Shift":           func() { arr.Shift() },
+		"Set":             func() { arr.Set(0, 10) },
+		"Insert":          func() { arr.Insert(1, 10) },
+		"Splice":          func() { arr.Splice(0, 1) },
+		"Replace":         func() { arr.Replace(0, 1, 10) },
+		"Concat":          func() { arr.Concat(NewArray([]int{4})) },
+		"Reverse":         func() { arr.Reverse() },
+		"Remove":          func() { arr.Remove(func(int, int) bool { return true }) },
+		"SortWith":        func() { arr.SortWith(func(a, b int) int { return a - b }) },
+		"SortWithReverse": func() { arr.SortWithReverse(func(a, b int) int { return a - b }) },
+		"Sort":            func() { Sort(arr, func(v int) int { return v }) },
+		"SortReverse":     func() { SortReverse(arr, func(v int) int { return v }) },
+		"Compact":         func() { Compact(arr) },
+		"View.Set":        func() { arr.View(0, 2).Set(0, 10) },
+	}
+	for name, mutate := range mutations {
+		assert.PanicsWithValue(ErrFrozen, mutate, name)
+	}
+
+	assert.Equal([]int{3, 1, 2}, arr.ToSlice())
+	assert.Equal(2, arr.At(-1))
+
+	copied := arr.Copy()
+	copied.Push(4)
+	assert.False(copied.IsFrozen())
+	assert.Equal([]int{3, 1, 2, 4}, copied.ToSlice())
+}
+
+func TestArraySliceIsCopy(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray(make([]int, 0, 10)).Push(1, 2, 3, 4)
+	slice := arr.Slice(1, 3)
+
+	slice.Set(0, 20)
+	slice.Push(30)
+
+	assert.Equal([]int{20, 3, 30}, slice.ToSlice())
+	assert.Equal([]int{1, 2, 3, 4}, arr.ToSlice())
+	assert.False(arr.Freeze().Slice(0, 1).IsFrozen())
+}
+
+func TestArrayView(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray(make([]int, 0, 10)).Push(1, 2, 3, 4)
+	view := arr.View(1, 3)
+
+	view.Set(0, 20)
+	assert.Equal([]int{1, 20, 3, 4}, arr.ToSlice())
+	arr.Set(2, 30)
+	assert.Equal([]int{20, 30}, view.ToSlice())
+
+	view.Push(5)
+	assert.Equal([]int{20, 30, 5}, view.ToSlice())
+	assert.Equal([]int{1, 20, 30, 4}, arr.ToSlice())
+}
+
+func TestArrayViewFrozenLater(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray([]int{1, 2, 3})
+	view := arr.View(0, 2)
+	arr.Freeze()
+
+	assert.True(view.IsFrozen())
+	assert.PanicsWithValue(ErrFrozen, func() { view.Set(0, 99) })
+	assert.PanicsWithValue(ErrFrozen, func() { view.View(0, 1).Set(0, 99) })
+	assert.Equal([]int{1, 2, 3}, arr.ToSlice())
+
+	// A view detached from the array by adding elements is not frozen
+	detached := NewArray([]int{1, 2, 3})
+	view = detached.View(0, 2).Push(4)
+	detached.Freeze()
+	assert.False(view.IsFrozen())
+	view.Set(0, 99)
+	assert.Equal([]int{1, 2, 3}, detached.ToSlice())
+}
+
+func TestArrayAliasing(t *testing.T) {
+	assert := assert.New(t)
+
+	newParent := func() *Array[int] {
+		return NewArray(make([]int, 0, 10)).Push(1, 1, 2, 3, 3)
+	}
+
+	mutations := map[string]func(*Array[int]){
+		"Push":    func(a *Array[int]) { a.Push(100) },
+		"Unshift": func(a *Array[int]) { a.Unshift(100) },
+		"Insert":  func(a *Array[int]) { a.Insert(1, 100) },
+		"Splice":  func(a *Array[int]) { a.Splice(0, 1) },
+		"Replace": func(a *Array[int]) { a.Replace(0, 1, 100, 100) },
+		"Concat":  func(a *Array[int]) { a.Concat(NewArray([]int{100})) },
+		"Remove":  func(a *Array[int]) { a.Remove(func(v, _ int) bool { return v == 1 }) },
+		"Pop":     func(a *Array[int]) { a.Pop(); a.Push(100) },
+		"Compact": func(a *Array[int]) { Compact(a).Push(100) },
+	}
+
+	for name, mutate := range mutations {
+		parent := newParent()
+		view := parent.View(0, 3)
+		mutate(view)
+		assert.Equal([]int{1, 1, 2, 3, 3}, parent.ToSlice(), "view "+name)
+
+		parent = newParent()
+		view = parent.View(0, 3)
+		mutate(parent)
+		assert.Equal([]int{1, 1, 2}, view.ToSlice(), "parent "+name)
+	}
+}
+
+func TestArrayVariadicArgumentsNotAliased(t *testing.T) {
+	assert := assert.New(t)
+
+	data := make([]int, 2, 10)
+	data[0], data[1] = 10, 20
+
+	arr := NewArray([]int{1, 2})
+	arr.Unshift(data...)
+	arr.Insert(1, data...)
+	arr.Set(0, 100)
+
+	assert.Equal([]int{10, 20}, data)
+	assert.Equal([]int{10, 20, 0, 0}, data[:4])
+	assert.Equal([]int{100, 10, 20, 20, 1, 2}, arr.ToSlice())
+}
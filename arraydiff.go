@@ -0,0 +1,216 @@
+package ezs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Kind of an operation of an edit script
+type EditKind int
+
+const (
+	EditKeep EditKind = iota
+	EditDelete
+	EditInsert
+)
+
+func (k EditKind) String() string {
+	switch k {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	}
+	return "unknown"
+}
+
+// Operation of an edit script turning one array into another. OldIndex
+// and NewIndex are the positions of the operation in the old and new
+// array, for deletions NewIndex is the position the deleted element
+// would have in the new array, and for insertions OldIndex is the
+// position the inserted element would have in the old array.
+type Edit[T any] struct {
+	Kind     EditKind
+	OldIndex int
+	NewIndex int
+	Value    T
+}
+
+// Computes the shortest edit script turning array {a} into array {b}
+// using Myers' O(ND) algorithm, where D is the number of inserted and
+// deleted elements. The elements are compared with the eq function.
+// Deletions come before insertions at the same position.
+func DiffArrays[T any](a, b *Array[T], eq func(T, T) bool) *Array[Edit[T]] {
+	return NewArray(myersDiff(a.data, b.data, eq))
+}
+
+func myersDiff[T any](a, b []T, eq func(T, T) bool) []Edit[T] {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	// Furthest reaching x of the diagonals -d-1..d+1 before each round,
+	// the only ones the backtracking of round d looks at
+	var trace [][]int
+
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, slices.Clone(v[offset-d-1:offset+d+2]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && eq(a[x], b[y]) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var edits []Edit[T]
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		// Index of diagonal 0 in the saved slice
+		o := d + 1
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[o+k-1] < v[o+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[o+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, Edit[T]{EditKeep, x, y, a[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			edits = append(edits, Edit[T]{EditInsert, x, prevY, b[prevY]})
+		} else {
+			edits = append(edits, Edit[T]{EditDelete, prevX, y, a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+
+	slices.Reverse(edits)
+	return edits
+}
+
+// Returns the longest sequence of elements appearing in both arrays in
+// the same order, not necessarily next to each other
+func LongestCommonSubsequence[T any](a, b *Array[T], eq func(T, T) bool) *Array[T] {
+	lcs := []T{}
+	for _, e := range myersDiff(a.data, b.data, eq) {
+		if e.Kind == EditKeep {
+			lcs = append(lcs, e.Value)
+		}
+	}
+	return NewArray(lcs)
+}
+
+// Applies the edit script to the array in place, turning the old array
+// of the script into the new one. Consecutive deletions and insertions
+// are applied with a single Splice or Insert call.
+func Patch[T any](array *Array[T], script *Array[Edit[T]]) *Array[T] {
+	edits := script.data
+	pos := 0
+	for i := 0; i < len(edits); {
+		j := i + 1
+		for j < len(edits) && edits[j].Kind == edits[i].Kind {
+			j++
+		}
+		switch edits[i].Kind {
+		case EditKeep:
+			pos += j - i
+		case EditDelete:
+			array.Splice(pos, j-i)
+		case EditInsert:
+			values := make([]T, 0, j-i)
+			for _, e := range edits[i:j] {
+				values = append(values, e.Value)
+			}
+			array.Insert(pos, values...)
+			pos += j - i
+		}
+		i = j
+	}
+	return array
+}
+
+// Renders the differences between the arrays as the hunks of a unified
+// diff, with {context} unchanged lines around each change. Returns an
+// empty string if the arrays are equal.
+func UnifiedDiff(a, b *Array[string], context int) string {
+	edits := myersDiff(a.data, b.data, func(x, y string) bool {
+		return x == y
+	})
+
+	var sb strings.Builder
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == EditKeep {
+			i++
+			continue
+		}
+
+		// Extends the hunk while the next change is close enough for
+		// the context lines to overlap
+		end := i
+		for j := i; j < len(edits); j++ {
+			if edits[j].Kind != EditKeep {
+				end = j
+			} else if j-end > 2*context {
+				break
+			}
+		}
+		start := max(0, i-context)
+		stop := min(len(edits), end+context+1)
+		writeHunk(&sb, edits[start:stop])
+		i = stop
+	}
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, edits []Edit[string]) {
+	oldStart, newStart := edits[0].OldIndex, edits[0].NewIndex
+	oldCount, newCount := 0, 0
+	for _, e := range edits {
+		if e.Kind != EditInsert {
+			oldCount++
+		}
+		if e.Kind != EditDelete {
+			newCount++
+		}
+	}
+	// Empty ranges start at the line before them
+	if oldCount > 0 {
+		oldStart++
+	}
+	if newCount > 0 {
+		newStart++
+	}
+
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	prefixes := map[EditKind]string{EditKeep: " ", EditDelete: "-", EditInsert: "+"}
+	for _, e := range edits {
+		sb.WriteString(prefixes[e.Kind])
+		sb.WriteString(e.Value)
+		sb.WriteString("\n")
+	}
+}
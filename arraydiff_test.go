@@ -0,0 +1,185 @@
+package ezs_test
+
+import (
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func eqString(a, b string) bool {
+	return a == b
+}
+
+func eqInt(a, b int) bool {
+	return a == b
+}
+
+func TestDiffArrays(t *testing.T) {
+	assert := assert.New(t)
+
+	a := NewArray(strings.Split("ABCABBA", ""))
+	b := NewArray(strings.Split("CBABAC", ""))
+
+	script := DiffArrays(a, b, eqString)
+
+	changes := 0
+	for e := range script.Iter() {
+		switch e.Kind {
+		case EditKeep:
+			assert.Equal(a.At(e.OldIndex), e.Value)
+			assert.Equal(b.At(e.NewIndex), e.Value)
+		case EditDelete:
+			assert.Equal(a.At(e.OldIndex), e.Value)
+			changes++
+		case EditInsert:
+			assert.Equal(b.At(e.NewIndex), e.Value)
+			changes++
+		}
+	}
+	assert.Equal(5, changes)
+	assert.Equal(b.ToSlice(), Patch(a.Copy(), script).ToSlice())
+}
+
+func TestDiffArraysEdgeCases(t *testing.T) {
+	assert := assert.New(t)
+
+	empty := NewArray([]int{})
+	values := NewArray([]int{1, 2})
+
+	assert.Equal(0, DiffArrays(empty, empty, eqInt).Length())
+	assert.Equal(
+		[]Edit[int]{{EditInsert, 0, 0, 1}, {EditInsert, 0, 1, 2}},
+		DiffArrays(empty, values, eqInt).ToSlice(),
+	)
+	assert.Equal(
+		[]Edit[int]{{EditDelete, 0, 0, 1}, {EditDelete, 1, 0, 2}},
+		DiffArrays(values, empty, eqInt).ToSlice(),
+	)
+	assert.Equal(
+		[]Edit[int]{{EditKeep, 0, 0, 1}, {EditKeep, 1, 1, 2}},
+		DiffArrays(values, values.Copy(), eqInt).ToSlice(),
+	)
+}
+
+// Length of the longest common subsequence using dynamic programming
+func lcsLength(a, b []int) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else {
+				dp[i][j] = max(dp[i-1][j], dp[i][j-1])
+			}
+		}
+	}
+	return dp[len(a)][len(b)]
+}
+
+func TestDiffArraysRandom(t *testing.T) {
+	assert := assert.New(t)
+	rng := rand.New(rand.NewSource(1))
+
+	randomArray := func() []int {
+		data := make([]int, rng.Intn(20))
+		for i := range data {
+			data[i] = rng.Intn(4)
+		}
+		return data
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := randomArray(), randomArray()
+		script := DiffArrays(NewArray(a), NewArray(b), eqInt)
+
+		assert.Equal(b, Patch(NewArray(append([]int{}, a...)), script).ToSlice())
+
+		lcs := LongestCommonSubsequence(NewArray(a), NewArray(b), eqInt)
+		assert.Equal(lcsLength(a, b), lcs.Length())
+		assert.Equal(len(a)+len(b)-2*lcs.Length(), script.Length()-lcs.Length())
+	}
+}
+
+func TestDiffArraysLarge(t *testing.T) {
+	assert := assert.New(t)
+	rng := rand.New(rand.NewSource(1))
+
+	a := make([]int, 200_000)
+	for i := range a {
+		a[i] = i
+	}
+	b := append([]int{}, a...)
+	for i := 0; i < 50; i++ {
+		b[rng.Intn(len(b))] = -1
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	script := DiffArrays(NewArray(a), NewArray(b), eqInt)
+	runtime.ReadMemStats(&after)
+
+	// Saving the whole diagonal array every round would take over
+	// 600MB here
+	assert.Less(after.TotalAlloc-before.TotalAlloc, uint64(64<<20))
+	assert.Equal(b, Patch(NewArray(append([]int{}, a...)), script).ToSlice())
+}
+
+func TestLongestCommonSubsequence(t *testing.T) {
+	assert := assert.New(t)
+
+	lcs := LongestCommonSubsequence(
+		NewArray(strings.Split("XMJYAUZ", "")),
+		NewArray(strings.Split("MZJAWXU", "")),
+		eqString,
+	)
+	assert.Equal("MJAU", Join(lcs, ""))
+}
+
+func TestEditKindString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("keep", EditKeep.String())
+	assert.Equal("delete", EditDelete.String())
+	assert.Equal("insert", EditInsert.String())
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	lines := func(s string) *Array[string] {
+		return NewArray(strings.Split(s, " "))
+	}
+	a := lines("a b c d e f g h i j k l")
+	b := lines("a b X d e f g h i j k l m")
+
+	assert.Equal(
+		"@@ -2,3 +2,3 @@\n"+
+			" b\n"+
+			"-c\n"+
+			"+X\n"+
+			" d\n"+
+			"@@ -12,1 +12,2 @@\n"+
+			" l\n"+
+			"+m\n",
+		UnifiedDiff(a, b, 1),
+	)
+
+	assert.Equal(
+		"@@ -1,12 +1,13 @@\n"+
+			" a\n b\n-c\n+X\n d\n e\n f\n g\n h\n i\n j\n k\n l\n+m\n",
+		UnifiedDiff(a, b, 5),
+	)
+
+	assert.Equal(
+		"@@ -0,0 +1,1 @@\n+x\n",
+		UnifiedDiff(NewArray([]string{}), lines("x"), 3),
+	)
+	assert.Equal("", UnifiedDiff(a, a.Copy(), 3))
+}
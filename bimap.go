@@ -0,0 +1,179 @@
+package ezs
+
+import "errors"
+
+// Returned by BiMap.Set when the value is already mapped to another key
+// and the map rejects duplicates
+var ErrDuplicateValue = errors.New("ezs: value is already mapped to another key")
+
+// Decides what BiMap.Set does when the value is already mapped to
+// another key
+type BiMapPolicy int
+
+const (
+	// Set fails with ErrDuplicateValue
+	RejectDuplicateValues BiMapPolicy = iota
+	// The entry of the other key is removed
+	ReplaceDuplicateValues
+)
+
+// Map in which every value is unique, so entries can be looked up both
+// by key and by value. Keys are kept in the order of insertion.
+type BiMap[K comparable, V comparable] struct {
+	forward  *Map[K, V]
+	backward *Map[V, K]
+	policy   BiMapPolicy
+	inverse  *BiMap[V, K]
+	iterIdx  int
+}
+
+func NewBiMap[K comparable, V comparable](policy BiMapPolicy) *BiMap[K, V] {
+	m := &BiMap[K, V]{
+		forward:  NewMap(map[K]V{}),
+		backward: NewMap(map[V]K{}),
+		policy:   policy,
+	}
+	m.inverse = &BiMap[V, K]{
+		forward:  m.backward,
+		backward: m.forward,
+		policy:   policy,
+		inverse:  m,
+	}
+	return m
+}
+
+// Returns the map with keys and values swapped. The inverse shares the
+// entries with this map, changes made to either are visible in both.
+func (m *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return m.inverse
+}
+
+func (m *BiMap[K, V]) Has(key K) bool {
+	return m.forward.Has(key)
+}
+
+func (m *BiMap[K, V]) HasValue(value V) bool {
+	return m.backward.Has(value)
+}
+
+func (m *BiMap[K, V]) Get(key K) (V, bool) {
+	return m.forward.Get(key)
+}
+
+// Returns the key mapped to the given value
+func (m *BiMap[K, V]) GetKey(value V) (K, bool) {
+	return m.backward.Get(value)
+}
+
+// Maps the key to the value. If the value is already mapped to another
+// key, either ErrDuplicateValue is returned or the entry of the other
+// key is removed, depending on the policy of the map.
+func (m *BiMap[K, V]) Set(key K, value V) error {
+	if otherKey, ok := m.backward.Get(value); ok {
+		if otherKey == key {
+			return nil
+		}
+		if m.policy == RejectDuplicateValues {
+			return ErrDuplicateValue
+		}
+		m.forward.Delete(otherKey)
+	}
+	if oldValue, ok := m.forward.Get(key); ok {
+		m.backward.Delete(oldValue)
+	}
+	m.forward.Set(key, value)
+	m.backward.Set(value, key)
+	return nil
+}
+
+// Removes the entry of the given key, returns false if there is no
+// such entry
+func (m *BiMap[K, V]) DeleteByKey(key K) bool {
+	value, ok := m.forward.Get(key)
+	if !ok {
+		return false
+	}
+	m.forward.Delete(key)
+	m.backward.Delete(value)
+	return true
+}
+
+// Removes the entry of the given value, returns false if there is no
+// such entry
+func (m *BiMap[K, V]) DeleteByValue(value V) bool {
+	return m.inverse.DeleteByKey(value)
+}
+
+func (m *BiMap[K, V]) Count() int {
+	return m.forward.Count()
+}
+
+func (m *BiMap[K, V]) Keys() *Array[K] {
+	return m.forward.Keys()
+}
+
+// Returns the values in the order of their keys
+func (m *BiMap[K, V]) Values() *Array[V] {
+	return MapTo(m.forward.Keys(), func(k K) V {
+		return m.forward.inner[k]
+	})
+}
+
+func (m *BiMap[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	return MapTo(m.forward.Keys(), func(k K) *MapEntry[K, V] {
+		return &MapEntry[K, V]{k, m.forward.inner[k]}
+	})
+}
+
+func (m *BiMap[K, V]) ForEach(fn func(key K, value V)) {
+	m.forward.ForEach(fn)
+}
+
+func (m *BiMap[K, V]) ToMap() map[K]V {
+	return m.forward.ToMap()
+}
+
+// Creates a shallow copy of the map with the same policy
+func (m *BiMap[K, V]) Copy() *BiMap[K, V] {
+	c := NewBiMap[K, V](m.policy)
+	for _, k := range m.forward.keys {
+		c.Set(k, m.forward.inner[k])
+	}
+	return c
+}
+
+func (m *BiMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*BiMap[K, V])
+	// The inverse is registered as copied along with this map, both are
+	// linked before copying the values in case these refer to them
+	inverse, _ := clonedPtr(s, from.inverse)
+	m.forward = NewMap(map[K]V{})
+	m.backward = NewMap(map[V]K{})
+	m.policy = from.policy
+	m.inverse = inverse
+	*inverse = BiMap[V, K]{forward: m.backward, backward: m.forward, policy: m.policy, inverse: m}
+
+	for _, k := range from.forward.keys {
+		m.forward.insert(k, cloneValue(s, from.forward.inner[k]))
+	}
+	for _, v := range from.backward.keys {
+		m.backward.insert(cloneValue(s, v), from.backward.inner[v])
+	}
+}
+
+func (m *BiMap[K, V]) Next() (*MapEntry[K, V], bool) {
+	if m.iterIdx < len(m.forward.keys) {
+		key := m.forward.keys[m.iterIdx]
+		m.iterIdx++
+		return &MapEntry[K, V]{key, m.forward.inner[key]}, false
+	}
+	return nil, true
+}
+
+func (m *BiMap[K, V]) IterReset() {
+	m.iterIdx = 0
+}
+
+func (m *BiMap[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return Iterator(m)
+}
@@ -0,0 +1,94 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiMapLookup(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewBiMap[int, string](RejectDuplicateValues)
+	assert.NoError(m.Set(1, "alice"))
+	assert.NoError(m.Set(2, "bob"))
+
+	name, ok := m.Get(1)
+	assert.True(ok)
+	assert.Equal("alice", name)
+
+	id, ok := m.GetKey("bob")
+	assert.True(ok)
+	assert.Equal(2, id)
+	assert.True(m.HasValue("alice"))
+	assert.False(m.Has(3))
+
+	assert.NoError(m.Set(1, "carol"))
+	assert.False(m.HasValue("alice"))
+	assert.Equal([]int{1, 2}, m.Keys().ToSlice())
+	assert.Equal([]string{"carol", "bob"}, m.Values().ToSlice())
+}
+
+func TestBiMapDuplicatePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	rejecting := NewBiMap[int, string](RejectDuplicateValues)
+	rejecting.Set(1, "alice")
+	assert.ErrorIs(rejecting.Set(2, "alice"), ErrDuplicateValue)
+	assert.NoError(rejecting.Set(1, "alice"))
+	assert.Equal(map[int]string{1: "alice"}, rejecting.ToMap())
+
+	replacing := NewBiMap[int, string](ReplaceDuplicateValues)
+	replacing.Set(1, "alice")
+	replacing.Set(2, "bob")
+	assert.NoError(replacing.Set(2, "alice"))
+	assert.Equal(map[int]string{2: "alice"}, replacing.ToMap())
+	assert.False(replacing.HasValue("bob"))
+	id, _ := replacing.GetKey("alice")
+	assert.Equal(2, id)
+}
+
+func TestBiMapDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewBiMap[int, string](RejectDuplicateValues)
+	m.Set(1, "alice")
+	m.Set(2, "bob")
+
+	assert.True(m.DeleteByKey(1))
+	assert.False(m.DeleteByKey(1))
+	assert.False(m.HasValue("alice"))
+
+	assert.True(m.DeleteByValue("bob"))
+	assert.False(m.DeleteByValue("bob"))
+	assert.False(m.Has(2))
+	assert.Equal(0, m.Count())
+}
+
+func TestBiMapInverse(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewBiMap[int, string](RejectDuplicateValues)
+	m.Set(1, "alice")
+	inverse := m.Inverse()
+
+	assert.NoError(inverse.Set("bob", 2))
+	assert.ErrorIs(inverse.Set("carol", 1), ErrDuplicateValue)
+
+	name, ok := m.Get(2)
+	assert.True(ok)
+	assert.Equal("bob", name)
+	assert.Same(m, inverse.Inverse())
+
+	keys := []string{}
+	for e := range inverse.Iter() {
+		keys = append(keys, e.Key)
+	}
+	assert.Equal([]string{"alice", "bob"}, keys)
+
+	copied := m.Copy()
+	copied.DeleteByKey(1)
+	assert.True(m.Has(1))
+	assert.Equal(1, copied.Count())
+}
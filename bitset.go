@@ -0,0 +1,321 @@
+package ezs
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/bits"
+)
+
+const wordSize = 64
+
+// Set of non-negative integers stored as a bit array. The set grows
+// as needed to fit the largest element.
+type BitSet struct {
+	words   []uint64
+	iterIdx int
+}
+
+func NewBitSet(data []int) *BitSet {
+	b := &BitSet{}
+	for _, i := range data {
+		b.Set(i)
+	}
+	return b
+}
+
+// Creates a new BitSet containing all the integers of the array
+func BitSetFromArray(array *Array[int]) *BitSet {
+	return NewBitSet(array.data)
+}
+
+func checkBitIndex(i int) {
+	if i < 0 {
+		panic("ezs: negative BitSet index")
+	}
+}
+
+func (b *BitSet) grow(words int) {
+	if words > len(b.words) {
+		b.words = append(b.words, make([]uint64, words-len(b.words))...)
+	}
+}
+
+// Adds the integer to the set
+func (b *BitSet) Set(i int) *BitSet {
+	checkBitIndex(i)
+	b.grow(i/wordSize + 1)
+	b.words[i/wordSize] |= 1 << (i % wordSize)
+	return b
+}
+
+// Removes the integer from the set
+func (b *BitSet) Clear(i int) *BitSet {
+	checkBitIndex(i)
+	if i/wordSize < len(b.words) {
+		b.words[i/wordSize] &^= 1 << (i % wordSize)
+	}
+	return b
+}
+
+// Adds the integer to the set if it's not in it, removes it otherwise
+func (b *BitSet) Flip(i int) *BitSet {
+	checkBitIndex(i)
+	b.grow(i/wordSize + 1)
+	b.words[i/wordSize] ^= 1 << (i % wordSize)
+	return b
+}
+
+// Returns true if the integer is in the set
+func (b *BitSet) Test(i int) bool {
+	if i < 0 || i/wordSize >= len(b.words) {
+		return false
+	}
+	return b.words[i/wordSize]&(1<<(i%wordSize)) != 0
+}
+
+// Applies the operation to the bits of each word in the range
+// [start, end), the mask passed to the operation has the bits of the
+// range set
+func (b *BitSet) applyRange(start, end int, op func(word *uint64, mask uint64)) {
+	checkBitIndex(start)
+	if end <= start {
+		return
+	}
+	for w := start / wordSize; w <= (end-1)/wordSize; w++ {
+		if w >= len(b.words) {
+			return
+		}
+		mask := ^uint64(0)
+		if w == start/wordSize {
+			mask &= ^uint64(0) << (start % wordSize)
+		}
+		if w == (end-1)/wordSize {
+			mask &= ^uint64(0) >> (wordSize - 1 - (end-1)%wordSize)
+		}
+		op(&b.words[w], mask)
+	}
+}
+
+// Adds all integers in the range [start, end) to the set
+func (b *BitSet) SetRange(start, end int) *BitSet {
+	if end > start {
+		b.grow((end-1)/wordSize + 1)
+	}
+	b.applyRange(start, end, func(word *uint64, mask uint64) { *word |= mask })
+	return b
+}
+
+// Removes all integers in the range [start, end) from the set
+func (b *BitSet) ClearRange(start, end int) *BitSet {
+	b.applyRange(start, end, func(word *uint64, mask uint64) { *word &^= mask })
+	return b
+}
+
+// Flips all integers in the range [start, end)
+func (b *BitSet) FlipRange(start, end int) *BitSet {
+	if end > start {
+		b.grow((end-1)/wordSize + 1)
+	}
+	b.applyRange(start, end, func(word *uint64, mask uint64) { *word ^= mask })
+	return b
+}
+
+// Returns the number of integers in the set
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the smallest integer in the set greater than or equal to
+// {from}
+func (b *BitSet) NextSet(from int) (int, bool) {
+	if from < 0 {
+		from = 0
+	}
+	w := from / wordSize
+	if w >= len(b.words) {
+		return 0, false
+	}
+	word := b.words[w] >> (from % wordSize)
+	if word != 0 {
+		return from + bits.TrailingZeros64(word), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return w*wordSize + bits.TrailingZeros64(b.words[w]), true
+		}
+	}
+	return 0, false
+}
+
+// Returns the smallest integer not in the set greater than or equal
+// to {from}
+func (b *BitSet) NextClear(from int) int {
+	if from < 0 {
+		from = 0
+	}
+	w := from / wordSize
+	if w >= len(b.words) {
+		return from
+	}
+	word := ^b.words[w] >> (from % wordSize)
+	if word != 0 {
+		return from + bits.TrailingZeros64(word)
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != ^uint64(0) {
+			return w*wordSize + bits.TrailingZeros64(^b.words[w])
+		}
+	}
+	return len(b.words) * wordSize
+}
+
+// Keeps in place only the integers that are also in the other set
+func (b *BitSet) And(other *BitSet) *BitSet {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+	return b
+}
+
+// Adds in place all the integers of the other set
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	b.grow(len(other.words))
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+	return b
+}
+
+// Keeps in place the integers that are in exactly one of the sets
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	b.grow(len(other.words))
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+	return b
+}
+
+// Removes in place all the integers of the other set
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &^= other.words[i]
+		}
+	}
+	return b
+}
+
+// Returns true if both sets contain the same integers
+func (b *BitSet) Equal(other *BitSet) bool {
+	for i := 0; i < max(len(b.words), len(other.words)); i++ {
+		var w1, w2 uint64
+		if i < len(b.words) {
+			w1 = b.words[i]
+		}
+		if i < len(other.words) {
+			w2 = other.words[i]
+		}
+		if w1 != w2 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) Copy() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &BitSet{words: words}
+}
+
+func (b *BitSet) deepCopyFrom(src any, _ *cloneState) {
+	b.words = src.(*BitSet).Copy().words
+}
+
+// Creates a new array with the integers of the set in ascending order
+func (b *BitSet) ToArray() *Array[int] {
+	arr := make([]int, 0, b.Count())
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		arr = append(arr, i)
+	}
+	return NewArray(arr)
+}
+
+func (b *BitSet) Next() (int, bool) {
+	if i, ok := b.NextSet(b.iterIdx); ok {
+		b.iterIdx = i + 1
+		return i, false
+	}
+	return 0, true
+}
+
+func (b *BitSet) IterReset() {
+	b.iterIdx = 0
+}
+
+// Returns an iterator over the integers of the set in ascending order
+func (b *BitSet) Iter() func(func(int) bool) {
+	return Iterator(b)
+}
+
+// Encodes the set as a sequence of little-endian 64-bit words
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	data := make([]byte, n*8)
+	for i, w := range b.words[:n] {
+		binary.LittleEndian.PutUint64(data[i*8:], w)
+	}
+	return data, nil
+}
+
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return errors.New("ezs: BitSet binary data length must be a multiple of 8")
+	}
+	b.words = make([]uint64, len(data)/8)
+	for i := range b.words {
+		b.words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	return nil
+}
+
+// Encodes the set as a JSON array of its integers in ascending order
+func (b *BitSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.ToArray().data)
+}
+
+func (b *BitSet) UnmarshalJSON(data []byte) error {
+	var ints []int
+	if err := json.Unmarshal(data, &ints); err != nil {
+		return err
+	}
+	b.words = nil
+	for _, i := range ints {
+		if i < 0 {
+			return errors.New("ezs: BitSet cannot contain negative integers")
+		}
+		b.Set(i)
+	}
+	return nil
+}
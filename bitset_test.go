@@ -0,0 +1,143 @@
+package ezs_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitSetSetClearTest(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewBitSet([]int{1, 64})
+	b.Set(3).Set(200).Clear(1).Clear(1000)
+
+	assert.False(b.Test(1))
+	assert.True(b.Test(3))
+	assert.True(b.Test(64))
+	assert.True(b.Test(200))
+	assert.False(b.Test(201))
+	assert.False(b.Test(-1))
+	assert.Equal(3, b.Count())
+
+	b.Flip(3).Flip(4)
+	assert.False(b.Test(3))
+	assert.True(b.Test(4))
+
+	assert.Panics(func() { b.Set(-1) })
+}
+
+func TestBitSetRanges(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewBitSet([]int{})
+	b.SetRange(60, 130)
+	assert.Equal(70, b.Count())
+	assert.True(b.Test(60))
+	assert.True(b.Test(129))
+	assert.False(b.Test(130))
+
+	b.ClearRange(62, 128)
+	assert.Equal([]int{60, 61, 128, 129}, b.ToArray().ToSlice())
+
+	b.FlipRange(59, 62)
+	assert.Equal([]int{59, 128, 129}, b.ToArray().ToSlice())
+
+	b.SetRange(5, 5)
+	assert.Equal(3, b.Count())
+}
+
+func TestBitSetNext(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewBitSet([]int{0, 1, 2, 70})
+
+	i, ok := b.NextSet(3)
+	assert.True(ok)
+	assert.Equal(70, i)
+
+	_, ok = b.NextSet(71)
+	assert.False(ok)
+
+	assert.Equal(3, b.NextClear(0))
+	assert.Equal(71, b.NextClear(70))
+	assert.Equal(500, b.NextClear(500))
+
+	full := NewBitSet([]int{}).SetRange(0, 64)
+	assert.Equal(64, full.NextClear(0))
+}
+
+func TestBitSetOperations(t *testing.T) {
+	assert := assert.New(t)
+
+	a := NewBitSet([]int{1, 2, 3, 100})
+	b := NewBitSet([]int{2, 3, 4})
+
+	assert.Equal([]int{2, 3}, a.Copy().And(b).ToArray().ToSlice())
+	assert.Equal([]int{1, 2, 3, 4, 100}, a.Copy().Or(b).ToArray().ToSlice())
+	assert.Equal([]int{1, 4, 100}, a.Copy().Xor(b).ToArray().ToSlice())
+	assert.Equal([]int{1, 100}, a.Copy().AndNot(b).ToArray().ToSlice())
+	assert.Equal([]int{4}, b.Copy().AndNot(a).ToArray().ToSlice())
+	assert.Equal([]int{1, 2, 3, 100}, a.ToArray().ToSlice())
+}
+
+func TestBitSetEqual(t *testing.T) {
+	assert := assert.New(t)
+
+	a := NewBitSet([]int{1, 2})
+	b := NewBitSet([]int{1, 2, 500}).Clear(500)
+
+	assert.True(a.Equal(b))
+	assert.True(b.Equal(a))
+	assert.False(a.Equal(NewBitSet([]int{1})))
+	assert.True(NewBitSet([]int{}).IsEmpty())
+	assert.True(b.Clear(1).Clear(2).IsEmpty())
+}
+
+func TestBitSetIter(t *testing.T) {
+	assert := assert.New(t)
+
+	b := BitSetFromArray(NewArray([]int{130, 5, 64, 5}))
+
+	iteratedOver := []int{}
+	for i := range b.Iter() {
+		iteratedOver = append(iteratedOver, i)
+	}
+
+	assert.Equal([]int{5, 64, 130}, iteratedOver)
+}
+
+func TestBitSetBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewBitSet([]int{0, 9, 65, 300}).Clear(300)
+
+	data, err := b.MarshalBinary()
+	assert.NoError(err)
+	assert.Len(data, 16)
+
+	decoded := NewBitSet([]int{7})
+	assert.NoError(decoded.UnmarshalBinary(data))
+	assert.True(b.Equal(decoded))
+
+	assert.Error(decoded.UnmarshalBinary([]byte{1, 2, 3}))
+}
+
+func TestBitSetJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewBitSet([]int{3, 1, 70})
+
+	data, err := json.Marshal(b)
+	assert.NoError(err)
+	assert.Equal(`[1,3,70]`, string(data))
+
+	decoded := &BitSet{}
+	assert.NoError(json.Unmarshal([]byte(`[2,4]`), decoded))
+	assert.Equal([]int{2, 4}, decoded.ToArray().ToSlice())
+
+	assert.Error(json.Unmarshal([]byte(`[-1]`), decoded))
+	assert.Error(json.Unmarshal([]byte(`"foo"`), decoded))
+}
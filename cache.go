@@ -0,0 +1,55 @@
+package ezs
+
+import "sync"
+
+// Common interface of the cache implementations, allows switching
+// between the eviction policies. Get counts as an access to the entry
+// while Peek does not, Keys, Entries and Iter list the entries
+// starting with the one that would be evicted last.
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Peek(key K) (V, bool)
+	Has(key K) bool
+	Set(key K, value V)
+	Delete(key K) bool
+	Clear()
+	Count() int
+	Capacity() int
+	Resize(capacity int)
+	Stats() CacheStats
+	ResetStats()
+	Keys() *Array[K]
+	Entries() *Array[*MapEntry[K, V]]
+	Iter() func(func(*MapEntry[K, V]) bool)
+}
+
+// Hit, miss and eviction counters of a cache
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// Returns the ratio of hits to all lookups, or 0 if there were no
+// lookups yet
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
+
+// Returns a new lock of the same kind as the given one, used by the
+// copies of the caches
+func newLockerLike(mu sync.Locker) sync.Locker {
+	if _, ok := mu.(noopLocker); ok {
+		return noopLocker{}
+	}
+	return &sync.Mutex{}
+}
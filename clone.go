@@ -0,0 +1,168 @@
+package ezs
+
+import "reflect"
+
+// Implemented by types which know how to create a deep copy of
+// themselves. DeepClone uses the Clone method instead of copying the
+// value on its own.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// Implemented by the collections of this package, so that they can be
+// deep copied despite having unexported fields
+type deepCopier interface {
+	deepCopyFrom(src any, s *cloneState)
+}
+
+type clonePtr struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type cloneState struct {
+	reflectStructs bool
+	// Copies of the already visited pointers and maps, used to
+	// preserve shared and cyclic references
+	seen map[clonePtr]reflect.Value
+}
+
+// Creates a deep copy of the value. Values implementing Cloner are
+// copied with their Clone method, pointers, slices, arrays, maps,
+// interfaces and the collections of this package are copied
+// recursively, and shared or cyclic references are preserved in the
+// copy. Map keys are not copied. Structs are copied by value unless
+// {reflectStructs} is true, in which case their exported fields are
+// deep copied as well, the unexported ones are always copied by value.
+// Copies of the collections keep their comparison and hashing
+// functions, but not the subscribers of observable collections nor the
+// janitor of a TTLMap.
+func DeepClone[T any](value T, reflectStructs bool) T {
+	s := &cloneState{
+		reflectStructs: reflectStructs,
+		seen:           make(map[clonePtr]reflect.Value),
+	}
+	return cloneValue(s, value)
+}
+
+func cloneValue[T any](s *cloneState, value T) T {
+	var result T
+	reflect.ValueOf(&result).Elem().Set(s.clone(reflect.ValueOf(&value).Elem()))
+	return result
+}
+
+// Returns the copy of the pointer, allocating and registering a zero
+// value as its copy if the pointer was not visited yet, in which case
+// the returned bool is true. Used by the collections made of linked
+// nodes, so that references to their nodes point to the copied nodes.
+func clonedPtr[T any](s *cloneState, p *T) (*T, bool) {
+	v := reflect.ValueOf(p)
+	key := clonePtr{v.Pointer(), v.Type()}
+	if c, ok := s.seen[key]; ok {
+		return c.Interface().(*T), false
+	}
+	c := new(T)
+	s.seen[key] = reflect.ValueOf(c)
+	return c, true
+}
+
+// Calls the Clone method of the value if it has one returning its own
+// type
+func (s *cloneState) cloneWithMethod(v reflect.Value) (reflect.Value, bool) {
+	if !v.CanInterface() || v.Kind() == reflect.Interface {
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return reflect.Value{}, false
+	}
+	m := v.MethodByName("Clone")
+	if !m.IsValid() {
+		return reflect.Value{}, false
+	}
+	mt := m.Type()
+	if mt.NumIn() != 0 || mt.NumOut() != 1 || mt.Out(0) != v.Type() {
+		return reflect.Value{}, false
+	}
+	return m.Call(nil)[0], true
+}
+
+func (s *cloneState) clone(v reflect.Value) reflect.Value {
+	if c, ok := s.cloneWithMethod(v); ok {
+		return c
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := clonePtr{v.Pointer(), v.Type()}
+		if c, ok := s.seen[key]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		s.seen[key] = c
+		if dc, ok := c.Interface().(deepCopier); ok {
+			dc.deepCopyFrom(v.Interface(), s)
+		} else {
+			c.Elem().Set(s.clone(v.Elem()))
+		}
+		return c
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(s.clone(v.Elem()))
+		return c
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(s.clone(v.Index(i)))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(s.clone(v.Index(i)))
+		}
+		return c
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := clonePtr{v.Pointer(), v.Type()}
+		if c, ok := s.seen[key]; ok {
+			return c
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		s.seen[key] = c
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), s.clone(iter.Value()))
+		}
+		return c
+
+	case reflect.Struct:
+		if !s.reflectStructs {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := c.Field(i); f.CanSet() {
+				f.Set(s.clone(v.Field(i)))
+			}
+		}
+		return c
+	}
+
+	return v
+}
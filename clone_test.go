@@ -0,0 +1,415 @@
+package ezs_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+type cloneUser struct {
+	Name  string
+	Tags  []string
+	Best  *cloneUser
+	notes []string
+}
+
+type clonedByMethod struct {
+	ID     int
+	Cloned bool
+}
+
+func (c *clonedByMethod) Clone() *clonedByMethod {
+	return &clonedByMethod{ID: c.ID, Cloned: true}
+}
+
+func TestArrayDeepCopy(t *testing.T) {
+	assert := assert.New(t)
+
+	alice := &cloneUser{Name: "alice", Tags: []string{"admin"}}
+	arr := NewArray([]*cloneUser{alice, alice, nil})
+
+	copied := arr.DeepCopy(false)
+	copied.At(0).Name = "bob"
+	copied.At(0).Tags[0] = "guest"
+
+	assert.Equal("alice", alice.Name)
+	assert.NotSame(alice, copied.At(0))
+	assert.Same(copied.At(0), copied.At(1))
+	assert.Nil(copied.At(2))
+	// Without reflectStructs the fields of the struct are shallow
+	assert.Equal("guest", alice.Tags[0])
+
+	alice.Tags[0] = "admin"
+	copied = arr.DeepCopy(true)
+	copied.At(0).Tags[0] = "guest"
+	assert.Equal("admin", alice.Tags[0])
+}
+
+func TestDeepCloneCycles(t *testing.T) {
+	assert := assert.New(t)
+
+	a := &cloneUser{Name: "a"}
+	b := &cloneUser{Name: "b", Best: a}
+	a.Best = b
+
+	cloned := DeepClone(a, true)
+
+	assert.NotSame(a, cloned)
+	assert.NotSame(b, cloned.Best)
+	assert.Same(cloned, cloned.Best.Best)
+	assert.Equal("b", cloned.Best.Name)
+}
+
+func TestDeepCloneUnexportedFields(t *testing.T) {
+	assert := assert.New(t)
+
+	user := &cloneUser{notes: []string{"note"}}
+	cloned := DeepClone(user, true)
+
+	cloned.notes[0] = "changed"
+	assert.Equal("changed", user.notes[0])
+}
+
+func TestDeepCloneCloner(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray([]*clonedByMethod{{ID: 1}})
+	copied := arr.DeepCopy(false)
+
+	assert.True(copied.At(0).Cloned)
+	assert.False(arr.At(0).Cloned)
+}
+
+func TestDeepCloneNestedCollections(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := NewArray([]int{1, 2})
+	m := NewMap(map[string]*Array[int]{})
+	m.Set("b", inner).Set("a", inner)
+
+	copied := m.DeepCopy(false)
+	copiedInner, _ := copied.Get("a")
+	copiedInner.Set(0, 100)
+	copiedInner.Push(3)
+
+	assert.Equal([]int{1, 2}, inner.ToSlice())
+	assert.Equal([]string{"b", "a"}, copied.Keys().ToSlice())
+	other, _ := copied.Get("b")
+	assert.Same(copiedInner, other)
+}
+
+func TestDeepCloneBuiltins(t *testing.T) {
+	assert := assert.New(t)
+
+	value := map[string][]any{
+		"list": {[]int{1}, map[int]string{1: "one"}, nil},
+	}
+	cloned := DeepClone(value, false)
+
+	cloned["list"][0].([]int)[0] = 100
+	cloned["list"][1].(map[int]string)[1] = "changed"
+
+	assert.Equal(1, value["list"][0].([]int)[0])
+	assert.Equal("one", value["list"][1].(map[int]string)[1])
+	assert.Nil(cloned["list"][2])
+
+	var nilSlice []int
+	assert.Nil(DeepClone(nilSlice, false))
+	var nilAny any
+	assert.Nil(DeepClone(nilAny, false))
+	assert.Equal([2]int{1, 2}, DeepClone([2]int{1, 2}, false))
+}
+
+type cloneBox struct {
+	N int
+}
+
+func TestDeepCloneList(t *testing.T) {
+	assert := assert.New(t)
+
+	box := &cloneBox{1}
+	list := NewList([]*cloneBox{box, box})
+	handle := list.Front().Next()
+
+	cloned := DeepClone(list, false)
+	cloned.PushBack(&cloneBox{3})
+	cloned.Front().Value.N = 10
+
+	assert.Equal(2, list.Length())
+	assert.Equal(1, box.N)
+	assert.Same(cloned.Front().Value, cloned.Back().Prev().Value)
+
+	type withHandle struct {
+		List   *List[*cloneBox]
+		Handle *Element[*cloneBox]
+	}
+	pair := DeepClone(withHandle{list, handle}, true)
+	assert.Same(pair.List.Front().Next(), pair.Handle)
+	assert.NotSame(handle, pair.Handle)
+}
+
+func TestDeepCloneSortedMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[int]*cloneBox{1: {1}, 2: {2}})
+	cloned := DeepClone(m, false)
+	cloned.Set(3, &cloneBox{3})
+	v, _ := cloned.Get(1)
+	v.N = 10
+
+	assert.Equal([]int{1, 2}, m.Keys().ToSlice())
+	assert.Equal([]int{1, 2, 3}, cloned.Keys().ToSlice())
+	v, _ = m.Get(1)
+	assert.Equal(1, v.N)
+}
+
+func TestDeepCloneBitSet(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewBitSet([]int{1, 5})
+	cloned := DeepClone(b, false)
+	cloned.Set(2).Clear(1)
+
+	assert.True(b.Test(1))
+	assert.False(b.Test(2))
+	assert.Equal(2, cloned.Count())
+}
+
+func TestDeepCloneGrid(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]*cloneBox{{{1}, {2}}})
+	cloned := DeepClone(g, false)
+	cloned.Set(0, 1, &cloneBox{20})
+	cloned.At(0, 0).N = 10
+
+	assert.Equal(1, g.At(0, 0).N)
+	assert.Equal(2, g.At(0, 1).N)
+}
+
+func TestDeepClonePrefixMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewPrefixMap(map[string]*cloneBox{"car": {1}, "cart": {2}})
+	cloned := DeepClone(m, false)
+	cloned.Set("cat", &cloneBox{3})
+	v, _ := cloned.Get("cart")
+	v.N = 20
+
+	assert.Equal(2, m.Count())
+	assert.False(m.Has("cat"))
+	v, _ = m.Get("cart")
+	assert.Equal(2, v.N)
+}
+
+func TestDeepCloneHashMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewHashMap[[]byte, *cloneBox](BytesHasher())
+	m.Set([]byte("a"), &cloneBox{1})
+	cloned := DeepClone(m, false)
+	cloned.Set([]byte("b"), &cloneBox{2})
+	v, _ := cloned.Get([]byte("a"))
+	v.N = 10
+	cloned.Delete([]byte("a"))
+
+	assert.Equal(1, m.Count())
+	v, ok := m.Get([]byte("a"))
+	assert.True(ok)
+	assert.Equal(1, v.N)
+}
+
+func TestDeepCloneNormalizedMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewCaseInsensitiveMap[*cloneBox]()
+	m.Set("Key", &cloneBox{1})
+	cloned := DeepClone(m, false)
+	cloned.Set("KEY", &cloneBox{2})
+
+	v, _ := m.Get("key")
+	assert.Equal(1, v.N)
+	v, _ = cloned.Get("key")
+	assert.Equal(2, v.N)
+}
+
+func TestDeepCloneBiMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewBiMap[string, int](RejectDuplicateValues)
+	m.Set("a", 1)
+	cloned := DeepClone(m, false)
+	cloned.Set("b", 2)
+	cloned.Inverse().Set(3, "c")
+
+	assert.Equal(map[string]int{"a": 1}, m.ToMap())
+	assert.Equal(map[string]int{"a": 1, "b": 2, "c": 3}, cloned.ToMap())
+	assert.Same(cloned, cloned.Inverse().Inverse())
+
+	inverse := DeepClone(m.Inverse(), false)
+	assert.Equal(map[string]int{"a": 1}, inverse.Inverse().ToMap())
+}
+
+func TestDeepCloneMultiMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSetMultiMap[string, int]()
+	m.AddAll("a", 1, 2)
+	cloned := DeepClone(m, false)
+	cloned.Add("a", 3).Add("a", 1).Remove("a", 2)
+
+	assert.Equal([]int{1, 2}, m.Get("a").ToSlice())
+	assert.Equal([]int{1, 3}, cloned.Get("a").ToSlice())
+	assert.Equal(2, cloned.CountValues())
+}
+
+func TestDeepCloneTree(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewTree[*cloneBox]()
+	root := tree.AddRoot(&cloneBox{1})
+	child := root.AddChild(&cloneBox{2})
+
+	cloned := DeepClone(tree, false)
+	clonedRoot := cloned.Roots().At(0)
+	clonedRoot.AddChild(&cloneBox{3})
+	clonedRoot.Children().At(0).Value.N = 20
+
+	assert.Equal(2, tree.Count())
+	assert.Equal(3, cloned.Count())
+	assert.Equal(2, child.Value.N)
+
+	clonedChild := DeepClone(child, false)
+	assert.Equal(1, clonedChild.Parent().Value.N)
+	assert.NotSame(root, clonedChild.Parent())
+	clonedChild.Detach()
+	assert.Equal(1, root.Children().Length())
+}
+
+func TestDeepCloneGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewUndirectedGraph[string, *cloneBox]()
+	g.AddEdge("a", "b", &cloneBox{1})
+	cloned := DeepClone(g, false)
+	cloned.AddEdge("b", "c", nil)
+	edge, _ := cloned.Edge("a", "b")
+	edge.Data.N = 10
+
+	assert.False(g.HasNode("c"))
+	original, _ := g.Edge("a", "b")
+	assert.Equal(1, original.Data.N)
+	reverse, _ := cloned.Edge("b", "a")
+	assert.Same(edge, reverse)
+}
+
+func TestDeepCloneCaches(t *testing.T) {
+	assert := assert.New(t)
+
+	caches := map[string]func() Cache[string, *cloneBox]{
+		"LRU": func() Cache[string, *cloneBox] { return NewSyncLRUCache[string, *cloneBox](2) },
+		"LFU": func() Cache[string, *cloneBox] { return NewSyncLFUCache[string, *cloneBox](2) },
+	}
+	for name, newCache := range caches {
+		c := newCache()
+		c.Set("a", &cloneBox{1})
+		c.Set("b", &cloneBox{2})
+
+		cloned := DeepClone(c, false)
+		cloned.Set("c", &cloneBox{3})
+		v, _ := cloned.Peek("b")
+		v.N = 20
+
+		assert.ElementsMatch([]string{"a", "b"}, c.Keys().ToSlice(), name)
+		assert.Equal(2, cloned.Count(), name)
+		v, _ = c.Peek("b")
+		assert.Equal(2, v.N, name)
+	}
+}
+
+func TestDeepCloneTTLMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewTTLMap[string, *cloneBox](time.Minute)
+	m.Set("a", &cloneBox{1})
+	cloned := DeepClone(m, false)
+	cloned.Set("b", &cloneBox{2})
+	v, _ := cloned.Get("a")
+	v.N = 10
+
+	assert.Equal([]string{"a"}, m.Keys().ToSlice())
+	v, _ = m.Get("a")
+	assert.Equal(1, v.N)
+}
+
+func TestDeepCloneImmutable(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewImmutableArray([]*cloneBox{{1}})
+	clonedArr := DeepClone(arr, false)
+	clonedArr.At(0).N = 10
+	assert.Equal(1, arr.At(0).N)
+	assert.Equal(1, clonedArr.Length())
+
+	m := NewImmutableMap(map[string]*cloneBox{"a": {1}})
+	clonedMap := DeepClone(m, false)
+	v, _ := clonedMap.Get("a")
+	v.N = 10
+	v, _ = m.Get("a")
+	assert.Equal(1, v.N)
+}
+
+func TestDeepCloneObservable(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewObservableArray([]int{1})
+	notifications := 0
+	arr.Subscribe(func([]ArrayChange[int]) { notifications++ })
+	clonedArr := DeepClone(arr, false)
+	clonedArr.Push(2)
+	assert.Equal([]int{1}, arr.ToSlice())
+	assert.Equal(0, notifications)
+
+	m := NewObservableMap(map[string]int{"a": 1})
+	clonedMap := DeepClone(m, false)
+	clonedMap.Set("b", 2)
+	assert.Equal(map[string]int{"a": 1}, m.ToMap())
+}
+
+func TestDeepCloneHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArrayHistory(NewObservableArray([]int{1}), 0)
+	arr.Push(2)
+	clonedArr := DeepClone(arr, false)
+	clonedArr.Push(3)
+	assert.True(clonedArr.Undo())
+	assert.True(clonedArr.Undo())
+	assert.Equal([]int{1}, clonedArr.ToSlice())
+	assert.Equal([]int{1, 2}, arr.ToSlice())
+	assert.True(arr.CanUndo())
+
+	m := NewMapHistory(NewObservableMap(map[string]int{}), 0)
+	m.Set("a", 1)
+	clonedMap := DeepClone(m, false)
+	clonedMap.Undo()
+	assert.Equal(map[string]int{}, clonedMap.ToMap())
+	assert.Equal(map[string]int{"a": 1}, m.ToMap())
+}
+
+func TestDeepCloneReadOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray([]*cloneBox{{1}})
+	cloned := DeepClone(arr.ReadOnly(), false)
+	cloned.At(0).N = 10
+	assert.Equal(1, arr.At(0).N)
+
+	m := NewMap(map[string]*cloneBox{"a": {1}})
+	clonedMap := DeepClone(m.ReadOnly(), false)
+	v, _ := clonedMap.Get("a")
+	v.N = 10
+	assert.Equal(1, m.GetOrDefault("a", nil).N)
+}
@@ -0,0 +1,462 @@
+package ezs
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrCycle = errors.New("graph contains a cycle")
+
+// Error returned by TopologicalSort, holds the nodes forming the
+// detected cycle, with the first node repeated at the end
+type CycleError[N comparable] struct {
+	Cycle *Array[N]
+}
+
+func (e *CycleError[N]) Error() string {
+	return fmt.Sprintf("%s: %v", ErrCycle.Error(), e.Cycle.data)
+}
+
+func (e *CycleError[N]) Unwrap() error {
+	return ErrCycle
+}
+
+type Edge[N comparable, E any] struct {
+	From   N
+	To     N
+	Weight float64
+	Data   E
+}
+
+// Graph of nodes of type N connected with edges carrying data of
+// type E. Nodes and edges are iterated over in insertion order.
+type Graph[N comparable, E any] struct {
+	directed bool
+	adj      *Map[N, *Map[N, *Edge[N, E]]]
+}
+
+func NewDirectedGraph[N comparable, E any]() *Graph[N, E] {
+	return &Graph[N, E]{
+		directed: true,
+		adj:      NewMap(map[N]*Map[N, *Edge[N, E]]{}),
+	}
+}
+
+func NewUndirectedGraph[N comparable, E any]() *Graph[N, E] {
+	return &Graph[N, E]{
+		directed: false,
+		adj:      NewMap(map[N]*Map[N, *Edge[N, E]]{}),
+	}
+}
+
+// Edges of an undirected graph are stored in both directions, their
+// copies are shared the same way
+func (g *Graph[N, E]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*Graph[N, E])
+	g.directed = from.directed
+	g.adj = NewMap(map[N]*Map[N, *Edge[N, E]]{})
+	for _, node := range from.adj.keys {
+		out := from.adj.inner[node]
+		copied := NewMap(map[N]*Edge[N, E]{})
+		for _, to := range out.keys {
+			e := out.inner[to]
+			c, created := clonedPtr(s, e)
+			if created {
+				*c = Edge[N, E]{From: e.From, To: e.To, Weight: e.Weight}
+				c.Data = cloneValue(s, e.Data)
+			}
+			copied.insert(to, c)
+		}
+		g.adj.insert(node, copied)
+	}
+}
+
+func (g *Graph[N, E]) IsDirected() bool {
+	return g.directed
+}
+
+// Adds the node to the graph, does nothing if it already exists
+func (g *Graph[N, E]) AddNode(node N) *Graph[N, E] {
+	if !g.adj.Has(node) {
+		g.adj.Set(node, NewMap(map[N]*Edge[N, E]{}))
+	}
+	return g
+}
+
+func (g *Graph[N, E]) HasNode(node N) bool {
+	return g.adj.Has(node)
+}
+
+// Removes the node and all the edges connected to it
+func (g *Graph[N, E]) RemoveNode(node N) *Graph[N, E] {
+	if !g.adj.Has(node) {
+		return g
+	}
+	g.adj.Delete(node)
+	for _, k := range g.adj.keys {
+		g.adj.inner[k].Delete(node)
+	}
+	return g
+}
+
+func (g *Graph[N, E]) NodeCount() int {
+	return g.adj.Count()
+}
+
+func (g *Graph[N, E]) Nodes() *Array[N] {
+	return g.adj.Keys()
+}
+
+// Adds an edge of weight 1 between the nodes, adding the nodes if
+// they don't exist. Replaces the existing edge between the nodes.
+func (g *Graph[N, E]) AddEdge(from, to N, data E) *Graph[N, E] {
+	return g.AddWeightedEdge(from, to, 1, data)
+}
+
+// Adds an edge of the given weight between the nodes, adding the nodes
+// if they don't exist. Replaces the existing edge between the nodes.
+// Panics if the weight is negative or NaN, which ShortestPath does not
+// support.
+func (g *Graph[N, E]) AddWeightedEdge(from, to N, weight float64, data E) *Graph[N, E] {
+	if weight < 0 || math.IsNaN(weight) {
+		panic(fmt.Sprintf("ezs: invalid edge weight %v", weight))
+	}
+	g.AddNode(from)
+	g.AddNode(to)
+	edge := &Edge[N, E]{From: from, To: to, Weight: weight, Data: data}
+	g.adj.inner[from].Set(to, edge)
+	if !g.directed {
+		g.adj.inner[to].Set(from, edge)
+	}
+	return g
+}
+
+func (g *Graph[N, E]) RemoveEdge(from, to N) *Graph[N, E] {
+	if out, ok := g.adj.Get(from); ok {
+		out.Delete(to)
+	}
+	if !g.directed {
+		if out, ok := g.adj.Get(to); ok {
+			out.Delete(from)
+		}
+	}
+	return g
+}
+
+func (g *Graph[N, E]) HasEdge(from, to N) bool {
+	_, ok := g.Edge(from, to)
+	return ok
+}
+
+// Returns the edge going from one node to the other
+func (g *Graph[N, E]) Edge(from, to N) (*Edge[N, E], bool) {
+	out, ok := g.adj.Get(from)
+	if !ok {
+		return nil, false
+	}
+	return out.Get(to)
+}
+
+// Returns all edges of the graph, edges of an undirected graph are
+// listed once
+func (g *Graph[N, E]) Edges() *Array[*Edge[N, E]] {
+	edges := []*Edge[N, E]{}
+	for _, from := range g.adj.keys {
+		out := g.adj.inner[from]
+		for _, to := range out.keys {
+			edge := out.inner[to]
+			if g.directed || edge.From == from {
+				edges = append(edges, edge)
+			}
+		}
+	}
+	return NewArray(edges)
+}
+
+// Returns the nodes reachable from the given node through a single
+// edge
+func (g *Graph[N, E]) Neighbors(node N) *Array[N] {
+	out, ok := g.adj.Get(node)
+	if !ok {
+		return NewArray([]N{})
+	}
+	return out.Keys()
+}
+
+// Returns an iterator visiting the nodes reachable from the start node
+// in breadth-first order
+func (g *Graph[N, E]) BFS(start N) func(func(N) bool) {
+	return func(yield func(N) bool) {
+		if !g.adj.Has(start) {
+			return
+		}
+		visited := map[N]bool{start: true}
+		queue := []N{start}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			if !yield(node) {
+				return
+			}
+			for _, next := range g.adj.inner[node].keys {
+				if !visited[next] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+}
+
+// Returns an iterator visiting the nodes reachable from the start node
+// in depth-first pre-order
+func (g *Graph[N, E]) DFS(start N) func(func(N) bool) {
+	return func(yield func(N) bool) {
+		if !g.adj.Has(start) {
+			return
+		}
+		visited := map[N]bool{}
+		stack := []N{start}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[node] {
+				continue
+			}
+			visited[node] = true
+			if !yield(node) {
+				return
+			}
+			neighbors := g.adj.inner[node].keys
+			for i := len(neighbors) - 1; i >= 0; i-- {
+				if !visited[neighbors[i]] {
+					stack = append(stack, neighbors[i])
+				}
+			}
+		}
+	}
+}
+
+// Returns the nodes ordered so that every node comes before all the
+// nodes its edges point to. Returns a *CycleError if the graph has a
+// cycle, any edge of an undirected graph is considered a cycle.
+func (g *Graph[N, E]) TopologicalSort() (*Array[N], error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[N]int, g.adj.Count())
+	order := make([]N, 0, g.adj.Count())
+	path := []N{}
+
+	var visit func(node N) error
+	visit = func(node N) error {
+		state[node] = visiting
+		path = append(path, node)
+		for _, next := range g.adj.inner[node].keys {
+			switch state[next] {
+			case visiting:
+				start := len(path) - 1
+				for path[start] != next {
+					start--
+				}
+				cycle := append(append([]N{}, path[start:]...), next)
+				return &CycleError[N]{Cycle: NewArray(cycle)}
+			case unvisited:
+				if err := visit(next); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = done
+		order = append(order, node)
+		return nil
+	}
+
+	for _, node := range g.adj.keys {
+		if state[node] == unvisited {
+			if err := visit(node); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return NewArray(order).Reverse(), nil
+}
+
+type dijkstraItem[N comparable] struct {
+	node N
+	dist float64
+}
+
+type dijkstraQueue[N comparable] []dijkstraItem[N]
+
+func (q dijkstraQueue[N]) Len() int           { return len(q) }
+func (q dijkstraQueue[N]) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q dijkstraQueue[N]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *dijkstraQueue[N]) Push(x any)        { *q = append(*q, x.(dijkstraItem[N])) }
+func (q *dijkstraQueue[N]) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+func (g *Graph[N, E]) dijkstra(from N, to *N) (map[N]float64, map[N]N) {
+	dist := map[N]float64{from: 0}
+	prev := map[N]N{}
+	visited := map[N]bool{}
+	queue := &dijkstraQueue[N]{{from, 0}}
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(dijkstraItem[N])
+		if visited[item.node] {
+			continue
+		}
+		visited[item.node] = true
+		if to != nil && item.node == *to {
+			break
+		}
+		out := g.adj.inner[item.node]
+		for _, next := range out.keys {
+			d := item.dist + out.inner[next].Weight
+			if current, ok := dist[next]; !ok || d < current {
+				dist[next] = d
+				prev[next] = item.node
+				heap.Push(queue, dijkstraItem[N]{next, d})
+			}
+		}
+	}
+
+	return dist, prev
+}
+
+// Finds the path with the lowest total weight between the nodes using
+// Dijkstra's algorithm. Returns the nodes of the path, including both
+// ends, and its total weight, or false if there is no path.
+func (g *Graph[N, E]) ShortestPath(from, to N) (*Array[N], float64, bool) {
+	if !g.adj.Has(from) || !g.adj.Has(to) {
+		return nil, 0, false
+	}
+
+	dist, prev := g.dijkstra(from, &to)
+	d, ok := dist[to]
+	if !ok {
+		return nil, 0, false
+	}
+
+	path := []N{to}
+	for node := to; node != from; {
+		node = prev[node]
+		path = append(path, node)
+	}
+	return NewArray(path).Reverse(), d, true
+}
+
+// Returns the lowest total weight of a path from the given node to
+// every node reachable from it
+func (g *Graph[N, E]) ShortestPaths(from N) *Map[N, float64] {
+	result := NewMap(map[N]float64{})
+	if !g.adj.Has(from) {
+		return result
+	}
+	dist, _ := g.dijkstra(from, nil)
+	for _, node := range g.adj.keys {
+		if d, ok := dist[node]; ok {
+			result.Set(node, d)
+		}
+	}
+	return result
+}
+
+// Returns the groups of nodes connected with each other, edges of a
+// directed graph are treated as undirected
+func (g *Graph[N, E]) ConnectedComponents() *Array[*Array[N]] {
+	undirected := map[N][]N{}
+	for _, from := range g.adj.keys {
+		for _, to := range g.adj.inner[from].keys {
+			undirected[from] = append(undirected[from], to)
+			if g.directed {
+				undirected[to] = append(undirected[to], from)
+			}
+		}
+	}
+
+	visited := map[N]bool{}
+	components := []*Array[N]{}
+	for _, start := range g.adj.keys {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		component := []N{}
+		queue := []N{start}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			component = append(component, node)
+			for _, next := range undirected[node] {
+				if !visited[next] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		components = append(components, NewArray(component))
+	}
+	return NewArray(components)
+}
+
+// Returns the groups of nodes where every node is reachable from every
+// other node of the group, computed with Tarjan's algorithm
+func (g *Graph[N, E]) StronglyConnectedComponents() *Array[*Array[N]] {
+	index := map[N]int{}
+	lowlink := map[N]int{}
+	onStack := map[N]bool{}
+	stack := []N{}
+	components := []*Array[N]{}
+	next := 0
+
+	var connect func(node N)
+	connect = func(node N) {
+		index[node] = next
+		lowlink[node] = next
+		next++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, to := range g.adj.inner[node].keys {
+			if _, seen := index[to]; !seen {
+				connect(to)
+				lowlink[node] = min(lowlink[node], lowlink[to])
+			} else if onStack[to] {
+				lowlink[node] = min(lowlink[node], index[to])
+			}
+		}
+
+		if lowlink[node] == index[node] {
+			component := []N{}
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == node {
+					break
+				}
+			}
+			components = append(components, NewArray(component).Reverse())
+		}
+	}
+
+	for _, node := range g.adj.keys {
+		if _, seen := index[node]; !seen {
+			connect(node)
+		}
+	}
+	return NewArray(components)
+}
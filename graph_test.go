@@ -0,0 +1,212 @@
+package ezs_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphNodesAndEdges(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewDirectedGraph[string, string]()
+	g.AddNode("a").
+		AddEdge("a", "b", "ab").
+		AddEdge("a", "c", "ac").
+		AddEdge("c", "b", "cb")
+
+	assert.Equal(3, g.NodeCount())
+	assert.Equal([]string{"a", "b", "c"}, g.Nodes().ToSlice())
+	assert.Equal([]string{"b", "c"}, g.Neighbors("a").ToSlice())
+	assert.Equal([]string{}, g.Neighbors("b").ToSlice())
+	assert.True(g.HasEdge("a", "b"))
+	assert.False(g.HasEdge("b", "a"))
+
+	edge, ok := g.Edge("c", "b")
+	assert.True(ok)
+	assert.Equal("cb", edge.Data)
+	assert.Equal(1.0, edge.Weight)
+
+	g.RemoveEdge("a", "b")
+	assert.False(g.HasEdge("a", "b"))
+
+	g.RemoveNode("c")
+	assert.Equal([]string{"a", "b"}, g.Nodes().ToSlice())
+	assert.Equal(0, g.Edges().Length())
+}
+
+func TestGraphUndirected(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewUndirectedGraph[int, struct{}]()
+	g.AddEdge(1, 2, struct{}{}).AddEdge(2, 3, struct{}{})
+
+	assert.False(g.IsDirected())
+	assert.True(g.HasEdge(2, 1))
+	assert.Equal([]int{1, 3}, g.Neighbors(2).ToSlice())
+	assert.Equal(2, g.Edges().Length())
+
+	g.RemoveEdge(2, 1)
+	assert.False(g.HasEdge(1, 2))
+	assert.Equal([]int{3}, g.Neighbors(2).ToSlice())
+}
+
+func TestGraphTraversal(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewDirectedGraph[string, int]()
+	g.AddEdge("a", "b", 0).
+		AddEdge("a", "c", 0).
+		AddEdge("b", "d", 0).
+		AddEdge("c", "d", 0).
+		AddEdge("d", "a", 0).
+		AddNode("e")
+
+	bfs := []string{}
+	for n := range g.BFS("a") {
+		bfs = append(bfs, n)
+	}
+
+	dfs := []string{}
+	for n := range g.DFS("a") {
+		dfs = append(dfs, n)
+	}
+
+	partial := []string{}
+	for n := range g.BFS("a") {
+		partial = append(partial, n)
+		if n == "b" {
+			break
+		}
+	}
+
+	assert.Equal([]string{"a", "b", "c", "d"}, bfs)
+	assert.Equal([]string{"a", "b", "d", "c"}, dfs)
+	assert.Equal([]string{"a", "b"}, partial)
+}
+
+func TestGraphTopologicalSort(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewDirectedGraph[string, any]()
+	g.AddEdge("app", "db", nil).
+		AddEdge("app", "cache", nil).
+		AddEdge("cache", "network", nil).
+		AddEdge("db", "network", nil).
+		AddNode("standalone")
+
+	order, err := g.TopologicalSort()
+	assert.NoError(err)
+
+	pos := func(n string) int { return IndexOf(order, n) }
+	assert.Equal(5, order.Length())
+	assert.Less(pos("app"), pos("db"))
+	assert.Less(pos("app"), pos("cache"))
+	assert.Less(pos("db"), pos("network"))
+	assert.Less(pos("cache"), pos("network"))
+
+	g.AddEdge("network", "app", nil)
+	_, err = g.TopologicalSort()
+
+	assert.ErrorIs(err, ErrCycle)
+	var cycleErr *CycleError[string]
+	assert.True(errors.As(err, &cycleErr))
+	assert.Equal(
+		[]string{"app", "db", "network", "app"},
+		cycleErr.Cycle.ToSlice(),
+	)
+}
+
+func TestGraphShortestPath(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewDirectedGraph[string, any]()
+	g.AddWeightedEdge("a", "b", 4, nil).
+		AddWeightedEdge("a", "c", 1, nil).
+		AddWeightedEdge("c", "b", 2, nil).
+		AddWeightedEdge("b", "d", 1, nil).
+		AddWeightedEdge("c", "d", 5, nil).
+		AddNode("e")
+
+	path, dist, ok := g.ShortestPath("a", "d")
+	assert.True(ok)
+	assert.Equal(4.0, dist)
+	assert.Equal([]string{"a", "c", "b", "d"}, path.ToSlice())
+
+	path, dist, ok = g.ShortestPath("a", "a")
+	assert.True(ok)
+	assert.Equal(0.0, dist)
+	assert.Equal([]string{"a"}, path.ToSlice())
+
+	_, _, ok = g.ShortestPath("a", "e")
+	assert.False(ok)
+
+	distances := g.ShortestPaths("a")
+	assert.Equal(
+		map[string]float64{"a": 0, "b": 3, "c": 1, "d": 4},
+		distances.ToMap(),
+	)
+	assert.Equal([]string{"a", "b", "c", "d"}, distances.Keys().ToSlice())
+}
+
+func TestGraphNegativeWeight(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewDirectedGraph[string, any]()
+	assert.PanicsWithValue("ezs: invalid edge weight -5", func() {
+		g.AddWeightedEdge("a", "b", -5, nil)
+	})
+	assert.Panics(func() {
+		g.AddWeightedEdge("a", "b", math.NaN(), nil)
+	})
+	assert.False(g.HasNode("a"))
+
+	g.AddWeightedEdge("a", "b", 0, nil)
+	_, dist, ok := g.ShortestPath("a", "b")
+	assert.True(ok)
+	assert.Equal(0.0, dist)
+}
+
+func TestGraphConnectedComponents(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewDirectedGraph[int, any]()
+	g.AddEdge(1, 2, nil).
+		AddEdge(3, 2, nil).
+		AddEdge(4, 5, nil).
+		AddNode(6)
+
+	components := MapTo(g.ConnectedComponents(), func(c *Array[int]) []int {
+		return c.ToSlice()
+	})
+
+	assert.Equal(
+		[][]int{{1, 2, 3}, {4, 5}, {6}},
+		components.ToSlice(),
+	)
+}
+
+func TestGraphStronglyConnectedComponents(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewDirectedGraph[string, any]()
+	g.AddEdge("a", "b", nil).
+		AddEdge("b", "c", nil).
+		AddEdge("c", "a", nil).
+		AddEdge("c", "d", nil).
+		AddEdge("d", "e", nil).
+		AddEdge("e", "d", nil).
+		AddNode("f")
+
+	components := MapTo(g.StronglyConnectedComponents(), func(c *Array[string]) []string {
+		return c.ToSlice()
+	})
+
+	assert.ElementsMatch(
+		[][]string{{"a", "b", "c"}, {"d", "e"}, {"f"}},
+		components.ToSlice(),
+	)
+}
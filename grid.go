@@ -0,0 +1,249 @@
+package ezs
+
+import "fmt"
+
+// Two-dimensional array with a fixed number of rows and columns, stored
+// in a single slice in row-major order
+type Grid[T any] struct {
+	data    []T
+	rows    int
+	cols    int
+	iterIdx int
+}
+
+type GridCell[T any] struct {
+	Row   int
+	Col   int
+	Value T
+}
+
+// Creates a new grid with all cells set to the zero value
+func NewGrid[T any](rows, cols int) *Grid[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("ezs: invalid grid size %dx%d", rows, cols))
+	}
+	return &Grid[T]{data: make([]T, rows*cols), rows: rows, cols: cols}
+}
+
+// Creates a new grid from a slice of rows. All rows must have the same
+// length.
+func GridFromSlices[T any](rows [][]T) *Grid[T] {
+	cols := 0
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+	g := NewGrid[T](len(rows), cols)
+	for r, row := range rows {
+		if len(row) != cols {
+			panic(fmt.Sprintf("ezs: grid row %d has length %d, expected %d", r, len(row), cols))
+		}
+		copy(g.data[r*cols:], row)
+	}
+	return g
+}
+
+func (g *Grid[T]) index(r, c int) int {
+	if r < 0 || r >= g.rows || c < 0 || c >= g.cols {
+		panic(fmt.Sprintf("ezs: grid index [%d, %d] out of range [%d, %d]", r, c, g.rows, g.cols))
+	}
+	return r*g.cols + c
+}
+
+func (g *Grid[T]) checkRow(r int) {
+	if r < 0 || r >= g.rows {
+		panic(fmt.Sprintf("ezs: grid row %d out of range %d", r, g.rows))
+	}
+}
+
+func (g *Grid[T]) checkCol(c int) {
+	if c < 0 || c >= g.cols {
+		panic(fmt.Sprintf("ezs: grid column %d out of range %d", c, g.cols))
+	}
+}
+
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// Returns true if the cell is within the grid bounds
+func (g *Grid[T]) InBounds(r, c int) bool {
+	return r >= 0 && r < g.rows && c >= 0 && c < g.cols
+}
+
+// Returns the value of the cell at the given row and column
+func (g *Grid[T]) At(r, c int) T {
+	return g.data[g.index(r, c)]
+}
+
+// Changes the value of the cell at the given row and column
+func (g *Grid[T]) Set(r, c int, value T) *Grid[T] {
+	g.data[g.index(r, c)] = value
+	return g
+}
+
+// Sets all cells to the given value
+func (g *Grid[T]) Fill(value T) *Grid[T] {
+	for i := range g.data {
+		g.data[i] = value
+	}
+	return g
+}
+
+// Returns a view of the given row, sharing its cells with the grid the
+// same way Array.View does. Values set through the view are visible in
+// the grid and the other way around, until elements are added to or
+// removed from the view or the grid is resized.
+func (g *Grid[T]) Row(r int) *Array[T] {
+	g.checkRow(r)
+	start := r * g.cols
+	row := NewArray(g.data[start : start+g.cols : start+g.cols])
+	row.shared = true
+	return row
+}
+
+// Returns a new array with the values of the given column. Unlike Row
+// it is a copy, the cells of a column are not next to each other in
+// the grid so an Array cannot share them.
+func (g *Grid[T]) Col(c int) *Array[T] {
+	g.checkCol(c)
+	col := make([]T, g.rows)
+	for r := range col {
+		col[r] = g.data[r*g.cols+c]
+	}
+	return NewArray(col)
+}
+
+// Creates a new grid with the rows and columns swapped
+func (g *Grid[T]) Transpose() *Grid[T] {
+	t := NewGrid[T](g.cols, g.rows)
+	for r := 0; r < g.rows; r++ {
+		for c := 0; c < g.cols; c++ {
+			t.data[c*t.cols+r] = g.data[r*g.cols+c]
+		}
+	}
+	return t
+}
+
+// Changes the size of the grid in place. Values of the cells within
+// both the old and new size are kept, new cells are set to the zero
+// value.
+func (g *Grid[T]) Resize(rows, cols int) *Grid[T] {
+	resized := NewGrid[T](rows, cols)
+	for r := 0; r < min(rows, g.rows); r++ {
+		copy(resized.data[r*cols:r*cols+min(cols, g.cols)], g.data[r*g.cols:])
+	}
+	g.data = resized.data
+	g.rows = rows
+	g.cols = cols
+	g.iterIdx = 0
+	return g
+}
+
+// Creates a new grid with a copy of the cells in the given rectangle
+func (g *Grid[T]) SubGrid(r, c, rows, cols int) *Grid[T] {
+	if r < 0 || c < 0 || rows < 0 || cols < 0 || r+rows > g.rows || c+cols > g.cols {
+		panic(fmt.Sprintf(
+			"ezs: subgrid [%d, %d] of size %dx%d out of range [%d, %d]",
+			r, c, rows, cols, g.rows, g.cols,
+		))
+	}
+	sub := NewGrid[T](rows, cols)
+	for i := 0; i < rows; i++ {
+		start := (r+i)*g.cols + c
+		copy(sub.data[i*cols:], g.data[start:start+cols])
+	}
+	return sub
+}
+
+// Creates a shallow copy of the grid
+func (g *Grid[T]) Copy() *Grid[T] {
+	return g.SubGrid(0, 0, g.rows, g.cols)
+}
+
+func (g *Grid[T]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*Grid[T])
+	g.rows = from.rows
+	g.cols = from.cols
+	g.data = make([]T, len(from.data))
+	for i, v := range from.data {
+		g.data[i] = cloneValue(s, v)
+	}
+}
+
+// Creates a new slice of rows with the values of the grid
+func (g *Grid[T]) ToSlices() [][]T {
+	rows := make([][]T, g.rows)
+	for r := range rows {
+		rows[r] = g.Row(r).ToSlice()
+	}
+	return rows
+}
+
+var (
+	gridOffsets4 = [][2]int{{-1, 0}, {0, -1}, {0, 1}, {1, 0}}
+	gridOffsets8 = [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+)
+
+// Returns an iterator over the cells adjacent to the given cell that
+// are within the grid. Only the 4 orthogonal neighbors are included,
+// unless {diagonal} is true, then all 8 are.
+func (g *Grid[T]) Neighbors(r, c int, diagonal bool) func(func(*GridCell[T]) bool) {
+	offsets := gridOffsets4
+	if diagonal {
+		offsets = gridOffsets8
+	}
+	return func(yield func(*GridCell[T]) bool) {
+		for _, o := range offsets {
+			nr, nc := r+o[0], c+o[1]
+			if !g.InBounds(nr, nc) {
+				continue
+			}
+			if !yield(&GridCell[T]{nr, nc, g.data[nr*g.cols+nc]}) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over all cells in row-major order
+func (g *Grid[T]) Cells() func(func(*GridCell[T]) bool) {
+	return func(yield func(*GridCell[T]) bool) {
+		for i, v := range g.data {
+			if !yield(&GridCell[T]{i / g.cols, i % g.cols, v}) {
+				return
+			}
+		}
+	}
+}
+
+func (g *Grid[T]) Next() (T, bool) {
+	if g.iterIdx < len(g.data) {
+		retVal := g.data[g.iterIdx]
+		g.iterIdx++
+		return retVal, false
+	}
+	var zero T
+	return zero, true
+}
+
+func (g *Grid[T]) IterReset() {
+	g.iterIdx = 0
+}
+
+// Returns an iterator over the values in row-major order
+func (g *Grid[T]) Iter() func(func(T) bool) {
+	return Iterator(g)
+}
+
+// Creates a new grid with the values converted by the mapper function
+func MapGrid[T any, U any](grid *Grid[T], mapper func(T) U) *Grid[U] {
+	mapped := NewGrid[U](grid.rows, grid.cols)
+	for i, v := range grid.data {
+		mapped.data[i] = mapper(v)
+	}
+	return mapped
+}
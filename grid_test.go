@@ -0,0 +1,186 @@
+package ezs_test
+
+import (
+	"strconv"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridAtAndSet(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewGrid[int](2, 3)
+	g.Set(0, 0, 1).Set(1, 2, 6)
+
+	assert.Equal(2, g.Rows())
+	assert.Equal(3, g.Cols())
+	assert.Equal(1, g.At(0, 0))
+	assert.Equal(6, g.At(1, 2))
+	assert.Equal(0, g.At(1, 0))
+	assert.True(g.InBounds(1, 2))
+	assert.False(g.InBounds(2, 0))
+
+	assert.Panics(func() { g.At(0, 3) })
+	assert.Panics(func() { g.Set(-1, 0, 1) })
+}
+
+func TestGridFromSlices(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]string{
+		{"a", "b"},
+		{"c", "d"},
+	})
+
+	assert.Equal("c", g.At(1, 0))
+	assert.Equal([][]string{{"a", "b"}, {"c", "d"}}, g.ToSlices())
+	assert.Panics(func() {
+		GridFromSlices([][]int{{1, 2}, {3}})
+	})
+}
+
+func TestGridRowAndCol(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	row := g.Row(1)
+	col := g.Col(2)
+	row.Set(0, 100)
+	g.Set(1, 1, 50)
+	assert.Equal([]int{100, 50, 6}, row.ToSlice())
+	assert.Equal([]int{3, 6}, col.ToSlice())
+	assert.Equal([][]int{{1, 2, 3}, {100, 50, 6}}, g.ToSlices())
+
+	row.Pop()
+	row.Push(7)
+	row.Set(0, 200)
+	assert.Equal([]int{200, 50, 7}, row.ToSlice())
+	assert.Equal([]int{100, 50, 6}, g.Row(1).ToSlice())
+	assert.Equal([]int{4, 5, 6}, GridFromSlices([][]int{{4, 5, 6}}).Row(0).ToSlice())
+
+	g.Col(2).Set(0, 300)
+	assert.Equal(3, g.At(0, 2))
+
+	assert.PanicsWithValue("ezs: grid row 2 out of range 2", func() { g.Row(2) })
+	assert.PanicsWithValue("ezs: grid column -1 out of range 3", func() { g.Col(-1) })
+}
+
+func TestGridRowAndColEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, NewGrid[int](0, 3).Col(2).Length())
+	assert.Equal(0, NewGrid[int](3, 0).Row(2).Length())
+	assert.Panics(func() { NewGrid[int](0, 3).Col(3) })
+}
+
+func TestGridTranspose(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	assert.Equal(
+		[][]int{{1, 4}, {2, 5}, {3, 6}},
+		g.Transpose().ToSlices(),
+	)
+}
+
+func TestMapGrid(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]int{{1, 2}, {3, 4}})
+	mapped := MapGrid(g, strconv.Itoa)
+
+	assert.Equal([][]string{{"1", "2"}, {"3", "4"}}, mapped.ToSlices())
+}
+
+func TestGridNeighbors(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+
+	collect := func(r, c int, diagonal bool) []int {
+		values := []int{}
+		for cell := range g.Neighbors(r, c, diagonal) {
+			values = append(values, cell.Value)
+		}
+		return values
+	}
+
+	assert.Equal([]int{2, 4, 6, 8}, collect(1, 1, false))
+	assert.Equal([]int{1, 2, 3, 4, 6, 7, 8, 9}, collect(1, 1, true))
+	assert.Equal([]int{2, 4}, collect(0, 0, false))
+	assert.Equal([]int{5, 6, 8}, collect(2, 2, true))
+}
+
+func TestGridResize(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	g.Resize(3, 2)
+	assert.Equal([][]int{{1, 2}, {4, 5}, {0, 0}}, g.ToSlices())
+
+	g.Resize(1, 4)
+	assert.Equal([][]int{{1, 2, 0, 0}}, g.ToSlices())
+}
+
+func TestGridSubGrid(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+
+	sub := g.SubGrid(1, 1, 2, 2)
+	sub.Set(0, 0, 50)
+
+	assert.Equal([][]int{{50, 6}, {8, 9}}, sub.ToSlices())
+	assert.Equal(5, g.At(1, 1))
+	assert.Panics(func() { g.SubGrid(2, 2, 2, 2) })
+	assert.Equal(3, g.SubGrid(0, 3, 3, 0).Rows())
+	assert.PanicsWithValue(
+		"ezs: subgrid [0, 4] of size 5x0 out of range [3, 3]",
+		func() { g.SubGrid(0, 4, 5, 0) },
+	)
+	assert.Panics(func() { g.SubGrid(-1, 0, 1, 1) })
+}
+
+func TestGridIter(t *testing.T) {
+	assert := assert.New(t)
+
+	g := GridFromSlices([][]int{{1, 2}, {3, 4}})
+
+	values := []int{}
+	for v := range g.Iter() {
+		values = append(values, v)
+	}
+
+	cells := []*GridCell[int]{}
+	for cell := range g.Cells() {
+		cells = append(cells, cell)
+	}
+
+	assert.Equal([]int{1, 2, 3, 4}, values)
+	assert.Equal(
+		[]*GridCell[int]{{0, 0, 1}, {0, 1, 2}, {1, 0, 3}, {1, 1, 4}},
+		cells,
+	)
+}
@@ -0,0 +1,262 @@
+package ezs
+
+import (
+	"bytes"
+	"hash/maphash"
+	"slices"
+)
+
+// Hash and equality functions of a key type. Keys that are equal must
+// have the same hash.
+type Hasher[K any] interface {
+	Hash(key K) uint64
+	Equal(a, b K) bool
+}
+
+// Implemented by key types that can hash and compare themselves
+type Hashable[K any] interface {
+	Hash() uint64
+	Equal(other K) bool
+}
+
+type funcHasher[K any] struct {
+	hash  func(K) uint64
+	equal func(a, b K) bool
+}
+
+func (h funcHasher[K]) Hash(key K) uint64 {
+	return h.hash(key)
+}
+
+func (h funcHasher[K]) Equal(a, b K) bool {
+	return h.equal(a, b)
+}
+
+// Creates a Hasher from the given functions
+func HasherFunc[K any](hash func(K) uint64, equal func(a, b K) bool) Hasher[K] {
+	return funcHasher[K]{hash, equal}
+}
+
+// Returns a Hasher using the methods of the Hashable keys
+func HashableHasher[K Hashable[K]]() Hasher[K] {
+	return HasherFunc(K.Hash, K.Equal)
+}
+
+var hasherSeed = maphash.MakeSeed()
+
+// Returns a Hasher for byte slices, comparing their contents
+func BytesHasher() Hasher[[]byte] {
+	return HasherFunc(func(b []byte) uint64 {
+		return maphash.Bytes(hasherSeed, b)
+	}, bytes.Equal)
+}
+
+func StringHasher() Hasher[string] {
+	return HasherFunc(func(s string) uint64 {
+		return maphash.String(hasherSeed, s)
+	}, func(a, b string) bool {
+		return a == b
+	})
+}
+
+// Returns a Hasher for Arrays, comparing their elements
+func ArrayHasher[T comparable]() Hasher[*Array[T]] {
+	return HasherFunc(func(a *Array[T]) uint64 {
+		var h maphash.Hash
+		h.SetSeed(hasherSeed)
+		for _, v := range a.data {
+			maphash.WriteComparable(&h, v)
+		}
+		return h.Sum64()
+	}, func(a, b *Array[T]) bool {
+		return slices.Equal(a.data, b.data)
+	})
+}
+
+type hashMapEntry[K any, V any] struct {
+	key   K
+	value V
+}
+
+// Map whose keys are hashed and compared by a Hasher, which allows
+// using keys that are not comparable, like slices or Arrays. Entries
+// are kept in the order of insertion. Keys must not be modified while
+// they are in the map.
+type HashMap[K any, V any] struct {
+	hasher  Hasher[K]
+	buckets map[uint64][]*hashMapEntry[K, V]
+	entries []*hashMapEntry[K, V]
+	iterIdx int
+}
+
+func NewHashMap[K any, V any](hasher Hasher[K]) *HashMap[K, V] {
+	return &HashMap[K, V]{
+		hasher:  hasher,
+		buckets: make(map[uint64][]*hashMapEntry[K, V]),
+	}
+}
+
+func (m *HashMap[K, V]) find(key K) (*hashMapEntry[K, V], uint64) {
+	hash := m.hasher.Hash(key)
+	for _, e := range m.buckets[hash] {
+		if m.hasher.Equal(e.key, key) {
+			return e, hash
+		}
+	}
+	return nil, hash
+}
+
+func (m *HashMap[K, V]) Has(key K) bool {
+	e, _ := m.find(key)
+	return e != nil
+}
+
+func (m *HashMap[K, V]) Get(key K) (V, bool) {
+	if e, _ := m.find(key); e != nil {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (m *HashMap[K, V]) Set(key K, value V) *HashMap[K, V] {
+	e, hash := m.find(key)
+	if e != nil {
+		e.value = value
+		return m
+	}
+	e = &hashMapEntry[K, V]{key, value}
+	m.buckets[hash] = append(m.buckets[hash], e)
+	m.entries = append(m.entries, e)
+	return m
+}
+
+func (m *HashMap[K, V]) Delete(key K) *HashMap[K, V] {
+	e, hash := m.find(key)
+	if e == nil {
+		return m
+	}
+
+	bucket := removeEntry(m.buckets[hash], e)
+	if len(bucket) == 0 {
+		delete(m.buckets, hash)
+	} else {
+		m.buckets[hash] = bucket
+	}
+	m.entries = removeEntry(m.entries, e)
+	return m
+}
+
+func removeEntry[K any, V any](entries []*hashMapEntry[K, V], e *hashMapEntry[K, V]) []*hashMapEntry[K, V] {
+	for i, other := range entries {
+		if other == e {
+			return append(entries[:i:i], entries[i+1:]...)
+		}
+	}
+	return entries
+}
+
+func (m *HashMap[K, V]) Count() int {
+	return len(m.entries)
+}
+
+func (m *HashMap[K, V]) Keys() *Array[K] {
+	keys := make([]K, len(m.entries))
+	for i, e := range m.entries {
+		keys[i] = e.key
+	}
+	return NewArray(keys)
+}
+
+func (m *HashMap[K, V]) Values() *Array[V] {
+	values := make([]V, len(m.entries))
+	for i, e := range m.entries {
+		values[i] = e.value
+	}
+	return NewArray(values)
+}
+
+// Same as MapEntry, but without requiring the key to be comparable
+type HashMapEntry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+func (m *HashMap[K, V]) Entries() *Array[*HashMapEntry[K, V]] {
+	entries := make([]*HashMapEntry[K, V], len(m.entries))
+	for i, e := range m.entries {
+		entries[i] = &HashMapEntry[K, V]{e.key, e.value}
+	}
+	return NewArray(entries)
+}
+
+func (m *HashMap[K, V]) ForEach(fn func(key K, value V)) {
+	for _, e := range m.entries {
+		fn(e.key, e.value)
+	}
+}
+
+func (m *HashMap[K, V]) Find(fn func(key K, value V) bool) (V, bool) {
+	for _, e := range m.entries {
+		if fn(e.key, e.value) {
+			return e.value, true
+		}
+	}
+	var zeroV V
+	return zeroV, false
+}
+
+func (m *HashMap[K, V]) FindKey(fn func(key K, value V) bool) (K, bool) {
+	for _, e := range m.entries {
+		if fn(e.key, e.value) {
+			return e.key, true
+		}
+	}
+	var zeroK K
+	return zeroK, false
+}
+
+// Creates a shallow copy of the map using the same Hasher
+func (m *HashMap[K, V]) Copy() *HashMap[K, V] {
+	c := NewHashMap[K, V](m.hasher)
+	for _, e := range m.entries {
+		c.Set(e.key, e.value)
+	}
+	return c
+}
+
+func (m *HashMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*HashMap[K, V])
+	m.hasher = from.hasher
+	m.entries = make([]*hashMapEntry[K, V], len(from.entries))
+	copies := make(map[*hashMapEntry[K, V]]*hashMapEntry[K, V], len(from.entries))
+	for i, e := range from.entries {
+		m.entries[i] = &hashMapEntry[K, V]{e.key, cloneValue(s, e.value)}
+		copies[e] = m.entries[i]
+	}
+	m.buckets = make(map[uint64][]*hashMapEntry[K, V], len(from.buckets))
+	for hash, bucket := range from.buckets {
+		copied := make([]*hashMapEntry[K, V], len(bucket))
+		for i, e := range bucket {
+			copied[i] = copies[e]
+		}
+		m.buckets[hash] = copied
+	}
+}
+
+func (m *HashMap[K, V]) Next() (*HashMapEntry[K, V], bool) {
+	if m.iterIdx < len(m.entries) {
+		e := m.entries[m.iterIdx]
+		m.iterIdx++
+		return &HashMapEntry[K, V]{e.key, e.value}, false
+	}
+	return nil, true
+}
+
+func (m *HashMap[K, V]) IterReset() {
+	m.iterIdx = 0
+}
+
+func (m *HashMap[K, V]) Iter() func(func(*HashMapEntry[K, V]) bool) {
+	return Iterator(m)
+}
@@ -0,0 +1,112 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+type point struct {
+	X, Y int
+	Tags []string
+}
+
+func (p point) Hash() uint64 {
+	return uint64(p.X*31 + p.Y)
+}
+
+func (p point) Equal(other point) bool {
+	return p.X == other.X && p.Y == other.Y
+}
+
+func TestHashMapBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewHashMap[[]byte, int](BytesHasher())
+	m.Set([]byte("foo"), 1).Set([]byte("bar"), 2)
+	m.Set([]byte("foo"), 10)
+
+	v, ok := m.Get([]byte("foo"))
+	assert.True(ok)
+	assert.Equal(10, v)
+	assert.Equal(2, m.Count())
+	assert.False(m.Has([]byte("baz")))
+
+	m.Delete([]byte("foo"))
+	assert.False(m.Has([]byte("foo")))
+	assert.Equal([][]byte{[]byte("bar")}, m.Keys().ToSlice())
+}
+
+func TestHashMapArrayKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewHashMap[*Array[int], string](ArrayHasher[int]())
+	m.Set(NewArray([]int{1, 2}), "a")
+	m.Set(NewArray([]int{2, 1}), "b")
+
+	v, ok := m.Get(NewArray([]int{1, 2}))
+	assert.True(ok)
+	assert.Equal("a", v)
+	assert.False(m.Has(NewArray([]int{1})))
+}
+
+func TestHashMapCollisions(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewHashMap[string, int](HasherFunc(
+		func(string) uint64 { return 0 },
+		func(a, b string) bool { return a == b },
+	))
+	m.Set("a", 1).Set("b", 2).Set("c", 3)
+
+	assert.Equal(3, m.Count())
+	v, _ := m.Get("b")
+	assert.Equal(2, v)
+
+	m.Delete("b")
+	assert.False(m.Has("b"))
+	assert.True(m.Has("a"))
+	assert.True(m.Has("c"))
+	assert.Equal([]string{"a", "c"}, m.Keys().ToSlice())
+}
+
+func TestHashMapHashable(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewHashMap[point, string](HashableHasher[point]())
+	m.Set(point{X: 1, Y: 2, Tags: []string{"a"}}, "first")
+	m.Set(point{X: 1, Y: 2}, "updated")
+	m.Set(point{X: 0, Y: 33}, "same hash")
+
+	assert.Equal(2, m.Count())
+	v, _ := m.Get(point{X: 1, Y: 2})
+	assert.Equal("updated", v)
+	v, _ = m.Get(point{X: 0, Y: 33})
+	assert.Equal("same hash", v)
+}
+
+func TestHashMapOrderAndIteration(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewHashMap[string, int](StringHasher())
+	m.Set("c", 3).Set("a", 1).Set("b", 2).Set("c", 30)
+
+	assert.Equal([]string{"c", "a", "b"}, m.Keys().ToSlice())
+	assert.Equal([]int{30, 1, 2}, m.Values().ToSlice())
+
+	keys := []string{}
+	for e := range m.Iter() {
+		keys = append(keys, e.Key)
+	}
+	assert.Equal([]string{"c", "a", "b"}, keys)
+
+	k, ok := m.FindKey(func(_ string, v int) bool { return v == 2 })
+	assert.True(ok)
+	assert.Equal("b", k)
+
+	copied := m.Copy()
+	copied.Delete("a")
+	assert.True(m.Has("a"))
+	assert.Equal(2, copied.Count())
+}
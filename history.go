@@ -0,0 +1,351 @@
+package ezs
+
+import (
+	"errors"
+	"maps"
+	"slices"
+)
+
+// Returned when restoring a checkpoint that was never created, or
+// whose state is no longer reachable through undo or redo
+var ErrCheckpointNotFound = errors.New("ezs: checkpoint not found")
+
+type historyStep[C any] struct {
+	id      uint64
+	changes []C
+}
+
+// Undo and redo stacks of the changes emitted by an observable
+// collection. Each state of the collection is identified by the id of
+// the last step applied to it, which is what checkpoints refer to.
+type historyStack[C any] struct {
+	depth       int
+	undo        []historyStep[C]
+	redo        []historyStep[C]
+	lastID      uint64
+	baseID      uint64
+	checkpoints map[string]uint64
+	groups      [][]C
+	applying    bool
+	apply       func([]C)
+	invert      func([]C) []C
+}
+
+func (h *historyStack[C]) init(depth int, apply func([]C), invert func([]C) []C) {
+	h.depth = depth
+	h.apply = apply
+	h.invert = invert
+	h.checkpoints = make(map[string]uint64)
+}
+
+func (h *historyStack[C]) record(changes []C) {
+	if h.applying || len(changes) == 0 {
+		return
+	}
+	if len(h.groups) > 0 {
+		top := len(h.groups) - 1
+		h.groups[top] = append(h.groups[top], changes...)
+		return
+	}
+
+	h.lastID++
+	h.undo = append(h.undo, historyStep[C]{h.lastID, slices.Clone(changes)})
+	h.redo = nil
+	if h.depth > 0 && len(h.undo) > h.depth {
+		h.baseID = h.undo[0].id
+		h.undo = slices.Delete(h.undo, 0, 1)
+	}
+}
+
+func (h *historyStack[C]) run(changes []C) {
+	h.applying = true
+	defer func() { h.applying = false }()
+	h.apply(changes)
+}
+
+func (h *historyStack[C]) stateID() uint64 {
+	if len(h.undo) == 0 {
+		return h.baseID
+	}
+	return h.undo[len(h.undo)-1].id
+}
+
+func (h *historyStack[C]) stepUndo() bool {
+	if len(h.undo) == 0 {
+		return false
+	}
+	step := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.run(h.invert(step.changes))
+	h.redo = append(h.redo, step)
+	return true
+}
+
+func (h *historyStack[C]) stepRedo() bool {
+	if len(h.redo) == 0 {
+		return false
+	}
+	step := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.run(step.changes)
+	h.undo = append(h.undo, step)
+	return true
+}
+
+func (h *historyStack[C]) checkpoint(name string) {
+	h.checkpoints[name] = h.stateID()
+}
+
+func (h *historyStack[C]) restore(name string) error {
+	id, ok := h.checkpoints[name]
+	if !ok {
+		return ErrCheckpointNotFound
+	}
+
+	hasID := func(s historyStep[C]) bool { return s.id == id }
+	switch {
+	case id == h.baseID || slices.ContainsFunc(h.undo, hasID):
+		for h.stateID() != id {
+			h.stepUndo()
+		}
+	case slices.ContainsFunc(h.redo, hasID):
+		for h.stateID() != id {
+			h.stepRedo()
+		}
+	default:
+		return ErrCheckpointNotFound
+	}
+	return nil
+}
+
+func (h *historyStack[C]) clear() {
+	h.undo = nil
+	h.redo = nil
+	h.baseID = h.lastID
+	clear(h.checkpoints)
+}
+
+// Copies the steps and checkpoints of the other stack, converting the
+// changes with the given function. Open transactions are not copied.
+func (h *historyStack[C]) copyFrom(from *historyStack[C], change func(C) C) {
+	copySteps := func(steps []historyStep[C]) []historyStep[C] {
+		copied := make([]historyStep[C], len(steps))
+		for i, step := range steps {
+			changes := make([]C, len(step.changes))
+			for j, c := range step.changes {
+				changes[j] = change(c)
+			}
+			copied[i] = historyStep[C]{step.id, changes}
+		}
+		return copied
+	}
+	h.undo = copySteps(from.undo)
+	h.redo = copySteps(from.redo)
+	h.lastID = from.lastID
+	h.baseID = from.baseID
+	h.checkpoints = maps.Clone(from.checkpoints)
+}
+
+// Runs the function collecting all the changes it makes. If the
+// function returns an error or panics the changes are reverted,
+// otherwise they are recorded as a single step, or added to the
+// enclosing transaction.
+func (h *historyStack[C]) transaction(fn func() error) error {
+	h.groups = append(h.groups, nil)
+	committed := false
+	defer func() {
+		group := h.groups[len(h.groups)-1]
+		h.groups = h.groups[:len(h.groups)-1]
+		if committed {
+			h.record(group)
+		} else if len(group) > 0 {
+			h.run(h.invert(group))
+		}
+	}()
+
+	if err := fn(); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+func invertArrayChanges[T any](changes []ArrayChange[T]) []ArrayChange[T] {
+	inverted := make([]ArrayChange[T], len(changes))
+	for i, c := range changes {
+		inv := ArrayChange[T]{Kind: c.Kind, Index: c.Index, OldValue: c.NewValue, NewValue: c.OldValue}
+		switch c.Kind {
+		case ChangeInsert:
+			inv.Kind = ChangeRemove
+		case ChangeRemove:
+			inv.Kind = ChangeInsert
+		}
+		inverted[len(changes)-1-i] = inv
+	}
+	return inverted
+}
+
+func invertMapChanges[K comparable, V any](changes []MapChange[K, V]) []MapChange[K, V] {
+	inverted := make([]MapChange[K, V], len(changes))
+	for i, c := range changes {
+		inv := MapChange[K, V]{Kind: c.Kind, Key: c.Key, Index: c.Index, OldValue: c.NewValue, NewValue: c.OldValue}
+		switch c.Kind {
+		case ChangeInsert:
+			inv.Kind = ChangeRemove
+		case ChangeRemove:
+			inv.Kind = ChangeInsert
+		}
+		inverted[len(changes)-1-i] = inv
+	}
+	return inverted
+}
+
+// Records the modifications of an ObservableArray and allows undoing
+// and redoing them. Every modifying call is a single step, as is every
+// Batch and Transaction. The methods of the history must not be called
+// within a Batch of the array.
+type ArrayHistory[T any] struct {
+	*ObservableArray[T]
+	history historyStack[ArrayChange[T]]
+}
+
+// Starts recording the modifications of the array, keeping at most
+// {depth} steps. A depth of zero or less means the history is not
+// limited.
+func NewArrayHistory[T any](arr *ObservableArray[T], depth int) *ArrayHistory[T] {
+	h := &ArrayHistory[T]{ObservableArray: arr}
+	h.history.init(depth, arr.apply, invertArrayChanges[T])
+	arr.Subscribe(h.history.record)
+	return h
+}
+
+// The copy records the modifications of a copy of the array
+func (h *ArrayHistory[T]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*ArrayHistory[T])
+	h.ObservableArray = cloneValue(s, from.ObservableArray)
+	h.history.init(from.history.depth, h.ObservableArray.apply, invertArrayChanges[T])
+	h.history.copyFrom(&from.history, func(c ArrayChange[T]) ArrayChange[T] {
+		c.OldValue = cloneValue(s, c.OldValue)
+		c.NewValue = cloneValue(s, c.NewValue)
+		return c
+	})
+	h.ObservableArray.Subscribe(h.history.record)
+}
+
+// Reverts the last step, returns false if there is nothing to undo
+func (h *ArrayHistory[T]) Undo() bool {
+	return h.history.stepUndo()
+}
+
+// Applies again the last undone step, returns false if there is
+// nothing to redo
+func (h *ArrayHistory[T]) Redo() bool {
+	return h.history.stepRedo()
+}
+
+func (h *ArrayHistory[T]) CanUndo() bool {
+	return len(h.history.undo) > 0
+}
+
+func (h *ArrayHistory[T]) CanRedo() bool {
+	return len(h.history.redo) > 0
+}
+
+// Saves the current state under the given name, replacing the previous
+// checkpoint of that name
+func (h *ArrayHistory[T]) Checkpoint(name string) {
+	h.history.checkpoint(name)
+}
+
+// Undoes or redoes the steps needed to get back to the state saved
+// under the given name
+func (h *ArrayHistory[T]) RestoreCheckpoint(name string) error {
+	return h.history.restore(name)
+}
+
+// Removes all the recorded steps and checkpoints
+func (h *ArrayHistory[T]) ClearHistory() {
+	h.history.clear()
+}
+
+// Runs the function and records the changes it made as a single step.
+// If the function returns an error or panics, all the changes it made
+// are reverted.
+func (h *ArrayHistory[T]) Transaction(fn func() error) error {
+	return h.history.transaction(fn)
+}
+
+// Records the modifications of an ObservableMap and allows undoing and
+// redoing them. Every modifying call is a single step, as is every
+// Batch and Transaction. The methods of the history must not be called
+// within a Batch of the map.
+type MapHistory[K comparable, V any] struct {
+	*ObservableMap[K, V]
+	history historyStack[MapChange[K, V]]
+}
+
+// Starts recording the modifications of the map, keeping at most
+// {depth} steps. A depth of zero or less means the history is not
+// limited.
+func NewMapHistory[K comparable, V any](m *ObservableMap[K, V], depth int) *MapHistory[K, V] {
+	h := &MapHistory[K, V]{ObservableMap: m}
+	h.history.init(depth, m.apply, invertMapChanges[K, V])
+	m.Subscribe(h.history.record)
+	return h
+}
+
+// The copy records the modifications of a copy of the map
+func (h *MapHistory[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*MapHistory[K, V])
+	h.ObservableMap = cloneValue(s, from.ObservableMap)
+	h.history.init(from.history.depth, h.ObservableMap.apply, invertMapChanges[K, V])
+	h.history.copyFrom(&from.history, func(c MapChange[K, V]) MapChange[K, V] {
+		c.OldValue = cloneValue(s, c.OldValue)
+		c.NewValue = cloneValue(s, c.NewValue)
+		return c
+	})
+	h.ObservableMap.Subscribe(h.history.record)
+}
+
+// Reverts the last step, returns false if there is nothing to undo
+func (h *MapHistory[K, V]) Undo() bool {
+	return h.history.stepUndo()
+}
+
+// Applies again the last undone step, returns false if there is
+// nothing to redo
+func (h *MapHistory[K, V]) Redo() bool {
+	return h.history.stepRedo()
+}
+
+func (h *MapHistory[K, V]) CanUndo() bool {
+	return len(h.history.undo) > 0
+}
+
+func (h *MapHistory[K, V]) CanRedo() bool {
+	return len(h.history.redo) > 0
+}
+
+// Saves the current state under the given name, replacing the previous
+// checkpoint of that name
+func (h *MapHistory[K, V]) Checkpoint(name string) {
+	h.history.checkpoint(name)
+}
+
+// Undoes or redoes the steps needed to get back to the state saved
+// under the given name
+func (h *MapHistory[K, V]) RestoreCheckpoint(name string) error {
+	return h.history.restore(name)
+}
+
+// Removes all the recorded steps and checkpoints
+func (h *MapHistory[K, V]) ClearHistory() {
+	h.history.clear()
+}
+
+// Runs the function and records the changes it made as a single step.
+// If the function returns an error or panics, all the changes it made
+// are reverted.
+func (h *MapHistory[K, V]) Transaction(fn func() error) error {
+	return h.history.transaction(fn)
+}
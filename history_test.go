@@ -0,0 +1,217 @@
+package ezs_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayHistoryUndoRedo(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewArrayHistory(NewObservableArray([]int{3, 1, 2}), 0)
+	assert.False(h.CanUndo())
+
+	h.Push(4)
+	h.Set(0, 30)
+	h.SortWith(func(a, b int) int { return a - b })
+	h.Splice(0, 2)
+	assert.Equal([]int{4, 30}, h.ToSlice())
+
+	assert.True(h.Undo())
+	assert.Equal([]int{1, 2, 4, 30}, h.ToSlice())
+	assert.True(h.Undo())
+	assert.Equal([]int{30, 1, 2, 4}, h.ToSlice())
+	assert.True(h.Undo())
+	assert.True(h.Undo())
+	assert.Equal([]int{3, 1, 2}, h.ToSlice())
+	assert.False(h.Undo())
+
+	assert.True(h.Redo())
+	assert.True(h.Redo())
+	assert.Equal([]int{30, 1, 2, 4}, h.ToSlice())
+	assert.True(h.CanRedo())
+
+	h.Pop()
+	assert.False(h.CanRedo())
+	assert.False(h.Redo())
+	assert.Equal([]int{30, 1, 2}, h.ToSlice())
+}
+
+func TestArrayHistoryNotifiesSubscribers(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewObservableArray([]string{})
+	h := NewArrayHistory(arr, 0)
+	var notifications [][]ArrayChange[string]
+	arr.Subscribe(func(changes []ArrayChange[string]) {
+		notifications = append(notifications, changes)
+	})
+
+	h.Push("a", "b")
+	h.Undo()
+
+	assert.Len(notifications, 2)
+	assert.Equal(
+		[]ArrayChange[string]{
+			{Kind: ChangeRemove, Index: 1, OldValue: "b"},
+			{Kind: ChangeRemove, Index: 0, OldValue: "a"},
+		},
+		notifications[1],
+	)
+}
+
+func TestArrayHistoryDepth(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewArrayHistory(NewObservableArray([]int{}), 2)
+	h.Push(1)
+	h.Push(2)
+	h.Push(3)
+
+	assert.True(h.Undo())
+	assert.True(h.Undo())
+	assert.False(h.Undo())
+	assert.Equal([]int{1}, h.ToSlice())
+}
+
+func TestArrayHistoryBatchIsSingleStep(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewArrayHistory(NewObservableArray([]int{}), 0)
+	h.Batch(func() {
+		h.Push(1, 2)
+		h.Unshift(0)
+	})
+
+	assert.True(h.Undo())
+	assert.Empty(h.ToSlice())
+	assert.False(h.CanUndo())
+}
+
+func TestArrayHistoryCheckpoints(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewArrayHistory(NewObservableArray([]int{}), 0)
+	h.Checkpoint("empty")
+	h.Push(1)
+	h.Push(2)
+	h.Checkpoint("two")
+	h.Push(3)
+
+	assert.NoError(h.RestoreCheckpoint("empty"))
+	assert.Empty(h.ToSlice())
+
+	assert.NoError(h.RestoreCheckpoint("two"))
+	assert.Equal([]int{1, 2}, h.ToSlice())
+
+	assert.ErrorIs(h.RestoreCheckpoint("missing"), ErrCheckpointNotFound)
+
+	h.Undo()
+	h.Push(5)
+	assert.ErrorIs(h.RestoreCheckpoint("two"), ErrCheckpointNotFound)
+	assert.NoError(h.RestoreCheckpoint("empty"))
+	assert.Empty(h.ToSlice())
+
+	h.ClearHistory()
+	assert.False(h.CanRedo())
+	assert.ErrorIs(h.RestoreCheckpoint("empty"), ErrCheckpointNotFound)
+}
+
+func TestArrayHistoryTransaction(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewArrayHistory(NewObservableArray([]int{1, 2, 3}), 0)
+	failure := errors.New("failure")
+
+	err := h.Transaction(func() error {
+		h.Push(4)
+		h.Shift()
+		return failure
+	})
+	assert.ErrorIs(err, failure)
+	assert.Equal([]int{1, 2, 3}, h.ToSlice())
+	assert.False(h.CanUndo())
+
+	assert.Panics(func() {
+		h.Transaction(func() error {
+			h.Set(0, 100)
+			panic("failed")
+		})
+	})
+	assert.Equal([]int{1, 2, 3}, h.ToSlice())
+
+	err = h.Transaction(func() error {
+		h.Push(4)
+		h.Transaction(func() error {
+			h.Push(5)
+			return failure
+		})
+		h.Push(6)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]int{1, 2, 3, 4, 6}, h.ToSlice())
+
+	assert.True(h.Undo())
+	assert.Equal([]int{1, 2, 3}, h.ToSlice())
+	assert.False(h.CanUndo())
+}
+
+func TestMapHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewMapHistory(NewObservableMap(map[string]int{"a": 1}), 0)
+	h.Set("b", 2)
+	h.Set("a", 10)
+	h.Delete("b")
+	assert.Equal(map[string]int{"a": 10}, h.ToMap())
+
+	h.Undo()
+	assert.Equal(map[string]int{"a": 10, "b": 2}, h.ToMap())
+	h.Undo()
+	h.Undo()
+	assert.Equal(map[string]int{"a": 1}, h.ToMap())
+
+	h.Redo()
+	assert.Equal(map[string]int{"a": 1, "b": 2}, h.ToMap())
+
+	err := h.Transaction(func() error {
+		h.Delete("a")
+		h.Set("c", 3)
+		return errors.New("failure")
+	})
+	assert.Error(err)
+	assert.Equal(map[string]int{"a": 1, "b": 2}, h.ToMap())
+}
+
+func TestMapHistoryKeepsKeyOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewObservableMap(map[string]int{})
+	m.Set("a", 1).Set("b", 2).Set("c", 3)
+	h := NewMapHistory(m, 0)
+
+	h.Delete("a")
+	h.Batch(func() {
+		h.Delete("c")
+		h.Set("d", 4)
+		h.Delete("b")
+	})
+	assert.Equal([]string{"d"}, h.Keys().ToSlice())
+
+	h.Undo()
+	assert.Equal([]string{"b", "c"}, h.Keys().ToSlice())
+	h.Undo()
+	assert.Equal([]string{"a", "b", "c"}, h.Keys().ToSlice())
+
+	h.Redo()
+	assert.Equal([]string{"b", "c"}, h.Keys().ToSlice())
+	h.Redo()
+	assert.Equal([]string{"d"}, h.Keys().ToSlice())
+	h.Undo()
+	h.Undo()
+	assert.Equal(map[string]int{"a": 1, "b": 2, "c": 3}, h.ToMap())
+}
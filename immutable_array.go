@@ -0,0 +1,325 @@
+package ezs
+
+const (
+	vecBits  = 5
+	vecWidth = 1 << vecBits
+	vecMask  = vecWidth - 1
+)
+
+type vecNode[T any] struct {
+	children []*vecNode[T]
+	values   []T
+}
+
+// Persistent array, every modification returns a new version of the
+// array which shares most of its structure with the previous one.
+// Updates are O(log n), and since versions are never modified in place
+// they can be safely shared between goroutines.
+type ImmutableArray[T any] struct {
+	length int
+	shift  int
+	root   *vecNode[T]
+	tail   []T
+}
+
+func NewImmutableArray[T any](data []T) *ImmutableArray[T] {
+	a := &ImmutableArray[T]{shift: vecBits, root: &vecNode[T]{}}
+	for _, v := range data {
+		a = a.Push(v)
+	}
+	return a
+}
+
+func (a *ImmutableArray[T]) tailOffset() int {
+	if a.length < vecWidth {
+		return 0
+	}
+	return ((a.length - 1) >> vecBits) << vecBits
+}
+
+// Returns the leaf values containing the given index
+func (a *ImmutableArray[T]) leafFor(idx int) []T {
+	if idx >= a.tailOffset() {
+		return a.tail
+	}
+	node := a.root
+	for level := a.shift; level > 0; level -= vecBits {
+		node = node.children[(idx>>level)&vecMask]
+	}
+	return node.values
+}
+
+func newVecPath[T any](level int, node *vecNode[T]) *vecNode[T] {
+	if level == 0 {
+		return node
+	}
+	return &vecNode[T]{children: []*vecNode[T]{newVecPath(level-vecBits, node)}}
+}
+
+func (a *ImmutableArray[T]) pushTail(level int, parent, tailNode *vecNode[T]) *vecNode[T] {
+	subidx := ((a.length - 1) >> level) & vecMask
+	ret := &vecNode[T]{children: make([]*vecNode[T], len(parent.children), subidx+1)}
+	copy(ret.children, parent.children)
+
+	var insert *vecNode[T]
+	if level == vecBits {
+		insert = tailNode
+	} else if subidx < len(parent.children) {
+		insert = a.pushTail(level-vecBits, parent.children[subidx], tailNode)
+	} else {
+		insert = newVecPath(level-vecBits, tailNode)
+	}
+
+	if subidx < len(ret.children) {
+		ret.children[subidx] = insert
+	} else {
+		ret.children = append(ret.children, insert)
+	}
+	return ret
+}
+
+func (a *ImmutableArray[T]) popTail(level int, node *vecNode[T]) *vecNode[T] {
+	subidx := ((a.length - 2) >> level) & vecMask
+	if level > vecBits {
+		child := a.popTail(level-vecBits, node.children[subidx])
+		if child == nil && subidx == 0 {
+			return nil
+		}
+		ret := &vecNode[T]{children: make([]*vecNode[T], subidx+1)}
+		copy(ret.children, node.children)
+		if child == nil {
+			ret.children = ret.children[:subidx]
+		} else {
+			ret.children[subidx] = child
+		}
+		return ret
+	}
+	if subidx == 0 {
+		return nil
+	}
+	ret := &vecNode[T]{children: make([]*vecNode[T], subidx)}
+	copy(ret.children, node.children)
+	return ret
+}
+
+func (a *ImmutableArray[T]) assoc(level int, node *vecNode[T], idx int, value T) *vecNode[T] {
+	if level == 0 {
+		values := make([]T, len(node.values))
+		copy(values, node.values)
+		values[idx&vecMask] = value
+		return &vecNode[T]{values: values}
+	}
+	children := make([]*vecNode[T], len(node.children))
+	copy(children, node.children)
+	subidx := (idx >> level) & vecMask
+	children[subidx] = a.assoc(level-vecBits, node.children[subidx], idx, value)
+	return &vecNode[T]{children: children}
+}
+
+func (a *ImmutableArray[T]) checkIndex(idx int) int {
+	if idx < 0 {
+		idx = a.length + idx
+	}
+	if idx < 0 || idx >= a.length {
+		panic("ezs: ImmutableArray index out of range")
+	}
+	return idx
+}
+
+// Returns the length of the array
+func (a *ImmutableArray[T]) Length() int {
+	return a.length
+}
+
+// Returns the element at the specified index
+func (a *ImmutableArray[T]) At(idx int) T {
+	idx = a.checkIndex(idx)
+	return a.leafFor(idx)[idx&vecMask]
+}
+
+// Returns a new array with the elements added to the end
+func (a *ImmutableArray[T]) Push(data ...T) *ImmutableArray[T] {
+	for _, v := range data {
+		a = a.push(v)
+	}
+	return a
+}
+
+func (a *ImmutableArray[T]) push(value T) *ImmutableArray[T] {
+	if a.length-a.tailOffset() < vecWidth {
+		tail := make([]T, len(a.tail), len(a.tail)+1)
+		copy(tail, a.tail)
+		return &ImmutableArray[T]{
+			length: a.length + 1,
+			shift:  a.shift,
+			root:   a.root,
+			tail:   append(tail, value),
+		}
+	}
+
+	tailNode := &vecNode[T]{values: a.tail}
+	shift := a.shift
+	var root *vecNode[T]
+	if (a.length >> vecBits) > (1 << a.shift) {
+		root = &vecNode[T]{children: []*vecNode[T]{
+			a.root,
+			newVecPath(a.shift, tailNode),
+		}}
+		shift += vecBits
+	} else {
+		root = a.pushTail(a.shift, a.root, tailNode)
+	}
+
+	return &ImmutableArray[T]{
+		length: a.length + 1,
+		shift:  shift,
+		root:   root,
+		tail:   []T{value},
+	}
+}
+
+// Returns a new array with the value at the specified index changed
+func (a *ImmutableArray[T]) Set(idx int, value T) *ImmutableArray[T] {
+	idx = a.checkIndex(idx)
+	if idx >= a.tailOffset() {
+		tail := make([]T, len(a.tail))
+		copy(tail, a.tail)
+		tail[idx&vecMask] = value
+		return &ImmutableArray[T]{length: a.length, shift: a.shift, root: a.root, tail: tail}
+	}
+	return &ImmutableArray[T]{
+		length: a.length,
+		shift:  a.shift,
+		root:   a.assoc(a.shift, a.root, idx, value),
+		tail:   a.tail,
+	}
+}
+
+// Returns a new array without the last element, along with that
+// element
+func (a *ImmutableArray[T]) Pop() (*ImmutableArray[T], T) {
+	last := a.At(-1)
+	if a.length == 1 {
+		return NewImmutableArray[T](nil), last
+	}
+
+	if a.length-a.tailOffset() > 1 {
+		return &ImmutableArray[T]{
+			length: a.length - 1,
+			shift:  a.shift,
+			root:   a.root,
+			tail:   a.tail[: len(a.tail)-1 : len(a.tail)-1],
+		}, last
+	}
+
+	tail := a.leafFor(a.length - 2)
+	root := a.popTail(a.shift, a.root)
+	shift := a.shift
+	if root == nil {
+		root = &vecNode[T]{}
+	}
+	if shift > vecBits && len(root.children) == 1 {
+		root = root.children[0]
+		shift -= vecBits
+	}
+	return &ImmutableArray[T]{length: a.length - 1, shift: shift, root: root, tail: tail}, last
+}
+
+// Returns the first element in the array that satisfies the
+// predicate
+func (a *ImmutableArray[T]) Find(predicate func(T, int) bool) (bool, T) {
+	for idx, v := range a.all() {
+		if predicate(v, idx) {
+			return true, v
+		}
+	}
+	var zero T
+	return false, zero
+}
+
+// Returns the index of the first element in the array that
+// satisfies the predicate
+func (a *ImmutableArray[T]) FindIndex(predicate func(T, int) bool) int {
+	for idx, v := range a.all() {
+		if predicate(v, idx) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Returns true if at least one element in the array satisfies
+// the predicate
+func (a *ImmutableArray[T]) Some(predicate func(T, int) bool) bool {
+	return a.FindIndex(predicate) != -1
+}
+
+// Returns true if all elements in the array satisfy the predicate
+func (a *ImmutableArray[T]) Every(predicate func(T, int) bool) bool {
+	return a.FindIndex(func(v T, idx int) bool { return !predicate(v, idx) }) == -1
+}
+
+func (a *ImmutableArray[T]) ForEach(callback func(T, int)) {
+	for idx, v := range a.all() {
+		callback(v, idx)
+	}
+}
+
+// Create a new array containing all the elements from the source array
+// that satisfy the given predicate
+func (a *ImmutableArray[T]) Filter(predicate func(T, int) bool) *ImmutableArray[T] {
+	filtered := NewImmutableArray[T](nil)
+	for idx, v := range a.all() {
+		if predicate(v, idx) {
+			filtered = filtered.push(v)
+		}
+	}
+	return filtered
+}
+
+func (a *ImmutableArray[T]) deepCopyFrom(src any, s *cloneState) {
+	values := src.(*ImmutableArray[T]).ToSlice()
+	for i, v := range values {
+		values[i] = cloneValue(s, v)
+	}
+	*a = *NewImmutableArray(values)
+}
+
+// Creates a new mutable Array with the elements of the array
+func (a *ImmutableArray[T]) ToArray() *Array[T] {
+	return NewArray(a.ToSlice())
+}
+
+// Creates a new slice with the elements of the array
+func (a *ImmutableArray[T]) ToSlice() []T {
+	s := make([]T, 0, a.length)
+	for v := range a.Iter() {
+		s = append(s, v)
+	}
+	return s
+}
+
+func (a *ImmutableArray[T]) all() func(func(int, T) bool) {
+	return func(yield func(int, T) bool) {
+		for start := 0; start < a.length; start += vecWidth {
+			for i, v := range a.leafFor(start) {
+				if !yield(start+i, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns an iterator over the elements of the array. Unlike the
+// mutable Array, the iterator holds no state in the array itself, so
+// the array can be iterated over from multiple goroutines.
+func (a *ImmutableArray[T]) Iter() func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, v := range a.all() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
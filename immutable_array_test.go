@@ -0,0 +1,138 @@
+package ezs_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmutableArrayPush(t *testing.T) {
+	assert := assert.New(t)
+
+	empty := NewImmutableArray([]int{})
+	one := empty.Push(1)
+	three := one.Push(2, 3)
+
+	assert.Equal(0, empty.Length())
+	assert.Equal([]int{1}, one.ToSlice())
+	assert.Equal([]int{1, 2, 3}, three.ToSlice())
+	assert.Equal(3, three.At(-1))
+}
+
+func TestImmutableArrayLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	expected := make([]int, 5000)
+	arr := NewImmutableArray([]int{})
+	versions := []*ImmutableArray[int]{}
+	for i := range expected {
+		expected[i] = i
+		arr = arr.Push(i)
+		if i%1000 == 0 {
+			versions = append(versions, arr)
+		}
+	}
+
+	assert.Equal(expected, arr.ToSlice())
+	for i, v := range versions {
+		assert.Equal(i*1000+1, v.Length())
+		assert.Equal(i*1000, v.At(-1))
+	}
+
+	updated := arr
+	for _, i := range rand.New(rand.NewSource(1)).Perm(5000)[:500] {
+		updated = updated.Set(i, -i)
+		expected[i] = -i
+	}
+	assert.Equal(expected, updated.ToSlice())
+	assert.Equal(4999, arr.At(4999))
+	assert.Equal(1234, arr.At(1234))
+}
+
+func TestImmutableArrayPop(t *testing.T) {
+	assert := assert.New(t)
+
+	data := make([]int, 1100)
+	for i := range data {
+		data[i] = i
+	}
+	arr := NewImmutableArray(data)
+
+	popped := arr
+	for i := len(data) - 1; i >= 0; i-- {
+		var last int
+		popped, last = popped.Pop()
+		assert.Equal(i, last)
+		assert.Equal(i, popped.Length())
+		if i > 0 && i%97 == 0 {
+			assert.Equal(data[:i], popped.ToSlice())
+		}
+	}
+
+	assert.Equal(0, popped.Length())
+	assert.Equal(data, arr.ToSlice())
+	assert.Equal([]int{7}, popped.Push(7).ToSlice())
+	assert.Panics(func() { popped.Pop() })
+}
+
+func TestImmutableArrayPopThenPushDoesNotAlias(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewImmutableArray([]int{1, 2, 3})
+	popped, _ := arr.Pop()
+	pushed := popped.Push(100)
+
+	assert.Equal([]int{1, 2, 3}, arr.ToSlice())
+	assert.Equal([]int{1, 2, 100}, pushed.ToSlice())
+}
+
+func TestImmutableArrayReadAPI(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewImmutableArray([]int{1, 2, 3, 4, 5})
+
+	ok, v := arr.Find(func(v, _ int) bool { return v > 3 })
+	assert.True(ok)
+	assert.Equal(4, v)
+	assert.Equal(2, arr.FindIndex(func(v, _ int) bool { return v == 3 }))
+	assert.True(arr.Some(func(v, _ int) bool { return v == 5 }))
+	assert.False(arr.Every(func(v, _ int) bool { return v < 5 }))
+	assert.Equal(
+		[]int{2, 4},
+		arr.Filter(func(v, _ int) bool { return v%2 == 0 }).ToSlice(),
+	)
+
+	sum := 0
+	arr.ForEach(func(v, idx int) { sum += v * idx })
+	assert.Equal(40, sum)
+
+	mutable := arr.ToArray()
+	mutable.Push(6)
+	assert.Equal(5, arr.Length())
+}
+
+func TestImmutableArrayConcurrentIter(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewImmutableArray([]int{1, 2, 3, 4})
+
+	var wg sync.WaitGroup
+	sums := make([]int, 8)
+	for g := range sums {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range arr.Iter() {
+				sums[g] += v
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, s := range sums {
+		assert.Equal(10, s)
+	}
+}
@@ -0,0 +1,343 @@
+package ezs
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+)
+
+var hamtSeed = maphash.MakeSeed()
+
+type hamtEntry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+// Either a sub-node or a single entry
+type hamtSlot[K comparable, V any] struct {
+	node  *hamtNode[K, V]
+	entry hamtEntry[K, V]
+}
+
+// Node of a hash array mapped trie. Once all the bits of the hash are
+// used up, keys with colliding hashes are stored in a list.
+type hamtNode[K comparable, V any] struct {
+	bitmap     uint32
+	slots      []hamtSlot[K, V]
+	collisions []hamtEntry[K, V]
+}
+
+func (n *hamtNode[K, V]) position(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[K, V]) get(hash uint64, key K, shift uint) (V, bool) {
+	for {
+		if shift >= 64 {
+			for _, e := range n.collisions {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			break
+		}
+		bit, idx := n.position(hash, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		slot := n.slots[idx]
+		if slot.node == nil {
+			if slot.entry.key == key {
+				return slot.entry.value, true
+			}
+			break
+		}
+		n = slot.node
+		shift += hamtBits
+	}
+	var zero V
+	return zero, false
+}
+
+func newHamtPair[K comparable, V any](a, b hamtEntry[K, V], shift uint) *hamtNode[K, V] {
+	if shift >= 64 {
+		return &hamtNode[K, V]{collisions: []hamtEntry[K, V]{a, b}}
+	}
+	idxA := (a.hash >> shift) & hamtMask
+	idxB := (b.hash >> shift) & hamtMask
+	n := &hamtNode[K, V]{bitmap: 1<<idxA | 1<<idxB}
+	switch {
+	case idxA == idxB:
+		n.slots = []hamtSlot[K, V]{{node: newHamtPair(a, b, shift+hamtBits)}}
+	case idxA < idxB:
+		n.slots = []hamtSlot[K, V]{{entry: a}, {entry: b}}
+	default:
+		n.slots = []hamtSlot[K, V]{{entry: b}, {entry: a}}
+	}
+	return n
+}
+
+// Returns a copy of the node with the entry added or replaced, and
+// whether the entry was added
+func (n *hamtNode[K, V]) set(e hamtEntry[K, V], shift uint) (*hamtNode[K, V], bool) {
+	if shift >= 64 {
+		collisions := make([]hamtEntry[K, V], len(n.collisions), len(n.collisions)+1)
+		copy(collisions, n.collisions)
+		for i, c := range collisions {
+			if c.key == e.key {
+				collisions[i] = e
+				return &hamtNode[K, V]{collisions: collisions}, false
+			}
+		}
+		return &hamtNode[K, V]{collisions: append(collisions, e)}, true
+	}
+
+	bit, idx := n.position(e.hash, shift)
+	slots := make([]hamtSlot[K, V], len(n.slots), len(n.slots)+1)
+	copy(slots, n.slots)
+
+	if n.bitmap&bit == 0 {
+		slots = append(slots, hamtSlot[K, V]{})
+		copy(slots[idx+1:], slots[idx:])
+		slots[idx] = hamtSlot[K, V]{entry: e}
+		return &hamtNode[K, V]{bitmap: n.bitmap | bit, slots: slots}, true
+	}
+
+	added := false
+	slot := slots[idx]
+	switch {
+	case slot.node != nil:
+		slots[idx].node, added = slot.node.set(e, shift+hamtBits)
+	case slot.entry.key == e.key:
+		slots[idx].entry = e
+	default:
+		slots[idx] = hamtSlot[K, V]{node: newHamtPair(slot.entry, e, shift+hamtBits)}
+		added = true
+	}
+	return &hamtNode[K, V]{bitmap: n.bitmap, slots: slots}, added
+}
+
+// Returns the only entry of the node if it has exactly one entry and
+// no sub-nodes
+func (n *hamtNode[K, V]) single() (hamtEntry[K, V], bool) {
+	if len(n.collisions) == 1 {
+		return n.collisions[0], true
+	}
+	if len(n.slots) == 1 && n.slots[0].node == nil {
+		return n.slots[0].entry, true
+	}
+	return hamtEntry[K, V]{}, false
+}
+
+func (n *hamtNode[K, V]) isEmpty() bool {
+	return len(n.slots) == 0 && len(n.collisions) == 0
+}
+
+// Returns a copy of the node without the entry, and whether the entry
+// was removed
+func (n *hamtNode[K, V]) delete(hash uint64, key K, shift uint) (*hamtNode[K, V], bool) {
+	if shift >= 64 {
+		for i, c := range n.collisions {
+			if c.key == key {
+				collisions := make([]hamtEntry[K, V], 0, len(n.collisions)-1)
+				collisions = append(collisions, n.collisions[:i]...)
+				collisions = append(collisions, n.collisions[i+1:]...)
+				return &hamtNode[K, V]{collisions: collisions}, true
+			}
+		}
+		return n, false
+	}
+
+	bit, idx := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	slot := n.slots[idx]
+	var replacement *hamtSlot[K, V]
+	if slot.node != nil {
+		child, removed := slot.node.delete(hash, key, shift+hamtBits)
+		if !removed {
+			return n, false
+		}
+		if entry, ok := child.single(); ok {
+			replacement = &hamtSlot[K, V]{entry: entry}
+		} else if !child.isEmpty() {
+			replacement = &hamtSlot[K, V]{node: child}
+		}
+	} else if slot.entry.key != key {
+		return n, false
+	}
+
+	if replacement != nil {
+		slots := make([]hamtSlot[K, V], len(n.slots))
+		copy(slots, n.slots)
+		slots[idx] = *replacement
+		return &hamtNode[K, V]{bitmap: n.bitmap, slots: slots}, true
+	}
+
+	slots := make([]hamtSlot[K, V], 0, len(n.slots)-1)
+	slots = append(slots, n.slots[:idx]...)
+	slots = append(slots, n.slots[idx+1:]...)
+	return &hamtNode[K, V]{bitmap: n.bitmap &^ bit, slots: slots}, true
+}
+
+func (n *hamtNode[K, V]) walk(fn func(K, V) bool) bool {
+	for _, e := range n.collisions {
+		if !fn(e.key, e.value) {
+			return false
+		}
+	}
+	for _, s := range n.slots {
+		if s.node != nil {
+			if !s.node.walk(fn) {
+				return false
+			}
+		} else if !fn(s.entry.key, s.entry.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Persistent map implemented as a hash array mapped trie. Set and
+// Delete return a new version of the map which shares most of its
+// structure with the previous one. Updates are O(log n), and since
+// versions are never modified in place they can be safely shared
+// between goroutines. The iteration order is not specified.
+type ImmutableMap[K comparable, V any] struct {
+	root  *hamtNode[K, V]
+	count int
+}
+
+func NewImmutableMap[K comparable, V any](inner map[K]V) *ImmutableMap[K, V] {
+	m := &ImmutableMap[K, V]{root: &hamtNode[K, V]{}}
+	for k, v := range inner {
+		m = m.Set(k, v)
+	}
+	return m
+}
+
+func (m *ImmutableMap[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+func (m *ImmutableMap[K, V]) Get(key K) (V, bool) {
+	return m.root.get(maphash.Comparable(hamtSeed, key), key, 0)
+}
+
+// Returns a new map with the entry added or replaced
+func (m *ImmutableMap[K, V]) Set(key K, value V) *ImmutableMap[K, V] {
+	e := hamtEntry[K, V]{maphash.Comparable(hamtSeed, key), key, value}
+	root, added := m.root.set(e, 0)
+	count := m.count
+	if added {
+		count++
+	}
+	return &ImmutableMap[K, V]{root: root, count: count}
+}
+
+// Returns a new map without the entry, or the same map if there is no
+// such entry
+func (m *ImmutableMap[K, V]) Delete(key K) *ImmutableMap[K, V] {
+	root, removed := m.root.delete(maphash.Comparable(hamtSeed, key), key, 0)
+	if !removed {
+		return m
+	}
+	return &ImmutableMap[K, V]{root: root, count: m.count - 1}
+}
+
+func (m *ImmutableMap[K, V]) Count() int {
+	return m.count
+}
+
+func (m *ImmutableMap[K, V]) Keys() *Array[K] {
+	keys := make([]K, 0, m.count)
+	m.ForEach(func(k K, _ V) {
+		keys = append(keys, k)
+	})
+	return NewArray(keys)
+}
+
+func (m *ImmutableMap[K, V]) Values() *Array[V] {
+	values := make([]V, 0, m.count)
+	m.ForEach(func(_ K, v V) {
+		values = append(values, v)
+	})
+	return NewArray(values)
+}
+
+func (m *ImmutableMap[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	entries := make([]*MapEntry[K, V], 0, m.count)
+	m.ForEach(func(k K, v V) {
+		entries = append(entries, &MapEntry[K, V]{k, v})
+	})
+	return NewArray(entries)
+}
+
+func (m *ImmutableMap[K, V]) ForEach(fn func(key K, value V)) {
+	m.root.walk(func(k K, v V) bool {
+		fn(k, v)
+		return true
+	})
+}
+
+func (m *ImmutableMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	copied := NewImmutableMap(map[K]V{})
+	src.(*ImmutableMap[K, V]).ForEach(func(k K, v V) {
+		copied = copied.Set(k, cloneValue(s, v))
+	})
+	*m = *copied
+}
+
+func (m *ImmutableMap[K, V]) ToMap() map[K]V {
+	newMap := make(map[K]V, m.count)
+	m.ForEach(func(k K, v V) {
+		newMap[k] = v
+	})
+	return newMap
+}
+
+func (m *ImmutableMap[K, V]) Find(fn func(key K, value V) bool) (V, bool) {
+	_, v, ok := m.findEntry(fn)
+	return v, ok
+}
+
+func (m *ImmutableMap[K, V]) FindKey(fn func(key K, value V) bool) (K, bool) {
+	k, _, ok := m.findEntry(fn)
+	return k, ok
+}
+
+func (m *ImmutableMap[K, V]) findEntry(fn func(key K, value V) bool) (K, V, bool) {
+	var (
+		foundK K
+		foundV V
+		found  bool
+	)
+	m.root.walk(func(k K, v V) bool {
+		if fn(k, v) {
+			foundK, foundV, found = k, v, true
+			return false
+		}
+		return true
+	})
+	return foundK, foundV, found
+}
+
+// Returns an iterator over the entries of the map. The iterator holds
+// no state in the map itself, so the map can be iterated over from
+// multiple goroutines.
+func (m *ImmutableMap[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return func(yield func(*MapEntry[K, V]) bool) {
+		m.root.walk(func(k K, v V) bool {
+			return yield(&MapEntry[K, V]{k, v})
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package ezs_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmutableMapSetAndGet(t *testing.T) {
+	assert := assert.New(t)
+
+	empty := NewImmutableMap(map[string]int{})
+	m1 := empty.Set("one", 1)
+	m2 := m1.Set("two", 2).Set("one", 10)
+
+	assert.Equal(0, empty.Count())
+	assert.Equal(1, m1.Count())
+	assert.Equal(2, m2.Count())
+
+	v, ok := m1.Get("one")
+	assert.True(ok)
+	assert.Equal(1, v)
+
+	v, ok = m2.Get("one")
+	assert.True(ok)
+	assert.Equal(10, v)
+
+	assert.False(m1.Has("two"))
+	assert.True(m2.Has("two"))
+}
+
+func TestImmutableMapLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	expected := map[int]int{}
+	m := NewImmutableMap(map[int]int{})
+	for i := 0; i < 5000; i++ {
+		m = m.Set(i, i*2)
+		expected[i] = i * 2
+	}
+	full := m
+
+	for _, i := range rand.New(rand.NewSource(2)).Perm(5000)[:2500] {
+		m = m.Delete(i)
+		delete(expected, i)
+	}
+	m = m.Delete(-1)
+
+	assert.Equal(2500, m.Count())
+	assert.Equal(expected, m.ToMap())
+	assert.Equal(5000, full.Count())
+	assert.Equal(5000, len(full.ToMap()))
+
+	for k := range expected {
+		m = m.Delete(k)
+	}
+	assert.Equal(0, m.Count())
+	assert.Equal(map[int]int{}, m.ToMap())
+}
+
+func TestImmutableMapReadAPI(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewImmutableMap(map[string]int{"one": 1, "two": 2, "three": 3})
+
+	assert.ElementsMatch([]string{"one", "two", "three"}, m.Keys().ToSlice())
+	assert.ElementsMatch([]int{1, 2, 3}, m.Values().ToSlice())
+	assert.ElementsMatch(
+		[]*MapEntry[string, int]{{"one", 1}, {"two", 2}, {"three", 3}},
+		m.Entries().ToSlice(),
+	)
+
+	v, ok := m.Find(func(k string, v int) bool { return k == "two" })
+	assert.True(ok)
+	assert.Equal(2, v)
+
+	k, ok := m.FindKey(func(k string, v int) bool { return v == 3 })
+	assert.True(ok)
+	assert.Equal("three", k)
+
+	_, ok = m.FindKey(func(k string, v int) bool { return v == 4 })
+	assert.False(ok)
+
+	count := 0
+	for range m.Iter() {
+		count++
+	}
+	assert.Equal(3, count)
+}
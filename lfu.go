@@ -0,0 +1,298 @@
+package ezs
+
+import "sync"
+
+type lfuItem[K comparable, V any] struct {
+	key    K
+	value  V
+	bucket *Element[*lfuBucket[K, V]]
+}
+
+// Items accessed the same number of times, ordered from the most to
+// the least recently used
+type lfuBucket[K comparable, V any] struct {
+	freq  int
+	items *List[*lfuItem[K, V]]
+}
+
+// Least-frequently-used cache. Once the capacity is reached, the entry
+// accessed the least number of times is evicted, from the entries with
+// the same access count the least recently used one goes first. All
+// operations are O(1).
+type LFUCache[K comparable, V any] struct {
+	items    map[K]*Element[*lfuItem[K, V]]
+	buckets  *List[*lfuBucket[K, V]]
+	capacity int
+	onEvict  func(key K, value V)
+	stats    CacheStats
+	mu       sync.Locker
+}
+
+// Creates a new LFU cache holding at most {capacity} entries. A
+// capacity of zero or less means the cache is never evicted.
+func NewLFUCache[K comparable, V any](capacity int) *LFUCache[K, V] {
+	return &LFUCache[K, V]{
+		items:    make(map[K]*Element[*lfuItem[K, V]]),
+		buckets:  NewList([]*lfuBucket[K, V]{}),
+		capacity: capacity,
+		mu:       noopLocker{},
+	}
+}
+
+// Creates a new LFU cache that can be safely used from multiple
+// goroutines
+func NewSyncLFUCache[K comparable, V any](capacity int) *LFUCache[K, V] {
+	c := NewLFUCache[K, V](capacity)
+	c.mu = &sync.Mutex{}
+	return c
+}
+
+// Sets the callback invoked with every entry evicted from the cache
+// due to the capacity limit
+func (c *LFUCache[K, V]) OnEvict(fn func(key K, value V)) *LFUCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = fn
+	return c
+}
+
+func (c *LFUCache[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*LFUCache[K, V])
+	from.mu.Lock()
+	defer from.mu.Unlock()
+
+	c.items = make(map[K]*Element[*lfuItem[K, V]], len(from.items))
+	c.buckets = NewList([]*lfuBucket[K, V]{})
+	for b := from.buckets.Front(); b != nil; b = b.Next() {
+		bucket := c.buckets.PushBack(&lfuBucket[K, V]{
+			freq:  b.Value.freq,
+			items: NewList([]*lfuItem[K, V]{}),
+		})
+		for e := b.Value.items.Front(); e != nil; e = e.Next() {
+			item := &lfuItem[K, V]{e.Value.key, cloneValue(s, e.Value.value), bucket}
+			c.items[item.key] = bucket.Value.items.PushBack(item)
+		}
+	}
+	c.capacity = from.capacity
+	c.onEvict = from.onEvict
+	c.stats = from.stats
+	c.mu = newLockerLike(from.mu)
+}
+
+// Moves the item to the bucket of the next access count
+func (c *LFUCache[K, V]) increment(e *Element[*lfuItem[K, V]]) {
+	item := e.Value
+	current := item.bucket
+	next := current.Next()
+	if next == nil || next.Value.freq != current.Value.freq+1 {
+		next = c.buckets.InsertAfter(&lfuBucket[K, V]{
+			freq:  current.Value.freq + 1,
+			items: NewList([]*lfuItem[K, V]{}),
+		}, current)
+	}
+
+	current.Value.items.Remove(e)
+	if current.Value.items.Length() == 0 {
+		c.buckets.Remove(current)
+	}
+
+	item.bucket = next
+	c.items[item.key] = next.Value.items.PushFront(item)
+}
+
+func (c *LFUCache[K, V]) remove(e *Element[*lfuItem[K, V]]) {
+	bucket := e.Value.bucket
+	bucket.Value.items.Remove(e)
+	if bucket.Value.items.Length() == 0 {
+		c.buckets.Remove(bucket)
+	}
+	delete(c.items, e.Value.key)
+}
+
+func (c *LFUCache[K, V]) evict(limit int) []*MapEntry[K, V] {
+	var evicted []*MapEntry[K, V]
+	for c.capacity > 0 && len(c.items) > limit {
+		e := c.buckets.Front().Value.items.Back()
+		c.remove(e)
+		c.stats.Evictions++
+		evicted = append(evicted, &MapEntry[K, V]{e.Value.key, e.Value.value})
+	}
+	return evicted
+}
+
+func (c *LFUCache[K, V]) notify(evicted []*MapEntry[K, V], onEvict func(key K, value V)) {
+	if onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		onEvict(e.Key, e.Value)
+	}
+}
+
+// Returns the value of the given key and increments its access count
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.increment(e)
+	return e.Value.value, true
+}
+
+// Returns the value of the given key without changing its access
+// count or the cache statistics
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Returns true if the key is in the cache, without changing its
+// access count
+func (c *LFUCache[K, V]) Has(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.items[key]
+	return ok
+}
+
+// Returns the number of times the entry has been accessed, including
+// the Set that added it
+func (c *LFUCache[K, V]) Frequency(key K) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	return e.Value.bucket.Value.freq, true
+}
+
+// Adds the entry or updates it and increments its access count. If
+// the cache is full, the least frequently used entry is evicted first.
+func (c *LFUCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok {
+		e.Value.value = value
+		c.increment(e)
+		c.mu.Unlock()
+		return
+	}
+
+	evicted := c.evict(c.capacity - 1)
+
+	first := c.buckets.Front()
+	if first == nil || first.Value.freq != 1 {
+		first = c.buckets.PushFront(&lfuBucket[K, V]{
+			freq:  1,
+			items: NewList([]*lfuItem[K, V]{}),
+		})
+	}
+	item := &lfuItem[K, V]{key: key, value: value, bucket: first}
+	c.items[key] = first.Value.items.PushFront(item)
+
+	onEvict := c.onEvict
+	c.mu.Unlock()
+	c.notify(evicted, onEvict)
+}
+
+// Removes the entry from the cache, returns false if there was no
+// such entry. The eviction callback is not called.
+func (c *LFUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.remove(e)
+	return true
+}
+
+// Removes all entries from the cache, without calling the eviction
+// callback
+func (c *LFUCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[K]*Element[*lfuItem[K, V]])
+	c.buckets = NewList([]*lfuBucket[K, V]{})
+}
+
+// Returns the number of entries in the cache
+func (c *LFUCache[K, V]) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Returns the maximum number of entries in the cache
+func (c *LFUCache[K, V]) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capacity
+}
+
+// Changes the capacity of the cache, evicting the least frequently
+// used entries if needed
+func (c *LFUCache[K, V]) Resize(capacity int) {
+	c.mu.Lock()
+	c.capacity = capacity
+	evicted := c.evict(capacity)
+	onEvict := c.onEvict
+	c.mu.Unlock()
+	c.notify(evicted, onEvict)
+}
+
+// Returns the hit, miss and eviction counters
+func (c *LFUCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Sets all the statistics counters back to zero
+func (c *LFUCache[K, V]) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = CacheStats{}
+}
+
+// Returns the keys ordered from the most to the least frequently
+// used, entries with the same access count are ordered from the most
+// to the least recently used
+func (c *LFUCache[K, V]) Keys() *Array[K] {
+	return MapTo(c.Entries(), func(e *MapEntry[K, V]) K {
+		return e.Key
+	})
+}
+
+// Returns the entries ordered from the most to the least frequently
+// used, entries with the same access count are ordered from the most
+// to the least recently used
+func (c *LFUCache[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := make([]*MapEntry[K, V], 0, len(c.items))
+	for b := c.buckets.Back(); b != nil; b = b.Prev() {
+		for e := b.Value.items.Front(); e != nil; e = e.Next() {
+			entries = append(entries, &MapEntry[K, V]{e.Value.key, e.Value.value})
+		}
+	}
+	return NewArray(entries)
+}
+
+// Returns an iterator over a snapshot of the entries, in the same
+// order as Entries. Iterating does not change the access counts.
+func (c *LFUCache[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return c.Entries().Iter()
+}
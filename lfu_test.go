@@ -0,0 +1,170 @@
+package ezs_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFUCacheEvictsLeastFrequent(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLFUCache[string, int](2)
+	c.Set("one", 1)
+	c.Set("two", 2)
+	c.Get("one")
+	c.Get("one")
+	c.Get("two")
+
+	c.Set("three", 3)
+
+	assert.True(c.Has("one"))
+	assert.False(c.Has("two"))
+	assert.True(c.Has("three"))
+
+	freq, ok := c.Frequency("one")
+	assert.True(ok)
+	assert.Equal(3, freq)
+}
+
+func TestLFUCacheTieBreaksByRecency(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLFUCache[string, int](3)
+	c.Set("one", 1)
+	c.Set("two", 2)
+	c.Set("three", 3)
+	c.Get("one")
+	c.Get("two")
+
+	c.Set("four", 4)
+	assert.False(c.Has("three"))
+
+	c.Set("five", 5)
+	assert.False(c.Has("four"))
+
+	c.Get("two")
+	assert.Equal(
+		[]string{"two", "one", "five"},
+		c.Keys().ToSlice(),
+	)
+}
+
+func TestLFUCacheSetUpdatesAndCounts(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLFUCache[string, int](2)
+	c.Set("one", 1)
+	c.Set("one", 10)
+	c.Set("two", 2)
+	c.Set("three", 3)
+
+	v, ok := c.Peek("one")
+	assert.True(ok)
+	assert.Equal(10, v)
+	assert.False(c.Has("two"))
+
+	freq, _ := c.Frequency("one")
+	assert.Equal(2, freq)
+}
+
+func TestLFUCacheDeleteAndClear(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLFUCache[string, int](3)
+	c.Set("one", 1)
+	c.Set("two", 2)
+	c.Get("two")
+
+	assert.True(c.Delete("two"))
+	assert.False(c.Delete("two"))
+	assert.Equal([]string{"one"}, c.Keys().ToSlice())
+
+	c.Clear()
+	assert.Equal(0, c.Count())
+	c.Set("three", 3)
+	assert.Equal([]string{"three"}, c.Keys().ToSlice())
+}
+
+func TestLFUCacheOnEvictAndResize(t *testing.T) {
+	assert := assert.New(t)
+
+	evicted := []string{}
+	c := NewLFUCache[string, int](3).OnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	c.Set("one", 1)
+	c.Set("two", 2)
+	c.Set("three", 3)
+	c.Get("one")
+	c.Get("three")
+
+	c.Resize(1)
+
+	assert.Equal([]string{"two", "one"}, evicted)
+	assert.Equal([]string{"three"}, c.Keys().ToSlice())
+	assert.Equal(uint64(2), c.Stats().Evictions)
+}
+
+func TestSyncLFUCache(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewSyncLFUCache[int, int](50)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				c.Set(g*100+i, i)
+				c.Get(g*100 + i/2)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	assert.Equal(50, c.Count())
+	assert.Equal(uint64(750), c.Stats().Evictions)
+}
+
+func testCacheInterface(t *testing.T, c Cache[string, int]) {
+	assert := assert.New(t)
+
+	c.Set("one", 1)
+	c.Set("two", 2)
+	c.Get("one")
+	c.Get("missing")
+
+	v, ok := c.Peek("two")
+	assert.True(ok)
+	assert.Equal(2, v)
+
+	c.Set("three", 3)
+
+	assert.Equal(2, c.Count())
+	assert.Equal(2, c.Capacity())
+	assert.False(c.Has("two"))
+	assert.ElementsMatch([]string{"one", "three"}, c.Keys().ToSlice())
+	assert.Equal(
+		CacheStats{Hits: 1, Misses: 1, Evictions: 1},
+		c.Stats(),
+	)
+
+	iteratedOver := []*MapEntry[string, int]{}
+	for e := range c.Iter() {
+		iteratedOver = append(iteratedOver, e)
+	}
+	assert.Equal(c.Entries().ToSlice(), iteratedOver)
+}
+
+func TestCacheInterface(t *testing.T) {
+	t.Run("LRU", func(t *testing.T) {
+		testCacheInterface(t, NewLRUCache[string, int](2))
+	})
+	t.Run("LFU", func(t *testing.T) {
+		testCacheInterface(t, NewLFUCache[string, int](2))
+	})
+}
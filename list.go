@@ -0,0 +1,299 @@
+package ezs
+
+// An element of a linked List. The handle stays valid for as long as
+// the element is part of the list, regardless of the insertions and
+// removals happening around it.
+type Element[T any] struct {
+	Value T
+	next  *Element[T]
+	prev  *Element[T]
+	list  *List[T]
+}
+
+// Returns the next element of the list or nil
+func (e *Element[T]) Next() *Element[T] {
+	if e.list != nil && e.next != &e.list.root {
+		return e.next
+	}
+	return nil
+}
+
+// Returns the previous element of the list or nil
+func (e *Element[T]) Prev() *Element[T] {
+	if e.list != nil && e.prev != &e.list.root {
+		return e.prev
+	}
+	return nil
+}
+
+// Doubly linked list. Insertion and removal of elements is O(1)
+// anywhere in the list.
+type List[T any] struct {
+	root     Element[T]
+	length   int
+	iterNext *Element[T]
+}
+
+func NewList[T any](data []T) *List[T] {
+	l := &List[T]{}
+	l.init()
+	for _, v := range data {
+		l.PushBack(v)
+	}
+	return l
+}
+
+func (l *List[T]) init() {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.length = 0
+	l.iterNext = nil
+}
+
+func (l *List[T]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*List[T])
+	l.init()
+	for e := from.Front(); e != nil; e = e.Next() {
+		c, _ := clonedPtr(s, e)
+		c.Value = cloneValue(s, e.Value)
+		l.insert(c, l.root.prev)
+	}
+}
+
+func (e *Element[T]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*Element[T])
+	if from.list == nil {
+		e.Value = cloneValue(s, from.Value)
+		return
+	}
+	// Copying the list fills this element, which is already registered
+	// as the copy of the original one
+	cloneValue(s, from.list)
+}
+
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.init()
+	}
+}
+
+func (l *List[T]) insert(e, at *Element[T]) *Element[T] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.length++
+	return e
+}
+
+func (l *List[T]) unlink(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+}
+
+func (l *List[T]) move(e, at *Element[T]) {
+	if e == at {
+		return
+	}
+	l.unlink(e)
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// Returns the length of the list
+func (l *List[T]) Length() int {
+	return l.length
+}
+
+// Returns the first element of the list or nil if the list is empty
+func (l *List[T]) Front() *Element[T] {
+	if l.length == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Returns the last element of the list or nil if the list is empty
+func (l *List[T]) Back() *Element[T] {
+	if l.length == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// Adds a new element to the beginning of the list and returns it
+func (l *List[T]) PushFront(value T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: value}, &l.root)
+}
+
+// Adds a new element to the end of the list and returns it
+func (l *List[T]) PushBack(value T) *Element[T] {
+	l.lazyInit()
+	return l.insert(&Element[T]{Value: value}, l.root.prev)
+}
+
+// Inserts a new element right before the given mark element and
+// returns it. If mark is not an element of this list, the list is
+// not modified and nil is returned.
+func (l *List[T]) InsertBefore(value T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: value}, mark.prev)
+}
+
+// Inserts a new element right after the given mark element and
+// returns it. If mark is not an element of this list, the list is
+// not modified and nil is returned.
+func (l *List[T]) InsertAfter(value T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: value}, mark)
+}
+
+// Removes the element from the list and returns its value
+func (l *List[T]) Remove(e *Element[T]) T {
+	if e.list == l {
+		if l.iterNext == e {
+			l.iterNext = e.next
+		}
+		l.unlink(e)
+		e.next = nil
+		e.prev = nil
+		e.list = nil
+		l.length--
+	}
+	return e.Value
+}
+
+// Moves the element to the beginning of the list
+func (l *List[T]) MoveToFront(e *Element[T]) {
+	if e.list != l || l.root.next == e {
+		return
+	}
+	l.move(e, &l.root)
+}
+
+// Moves the element to the end of the list
+func (l *List[T]) MoveToBack(e *Element[T]) {
+	if e.list != l || l.root.prev == e {
+		return
+	}
+	l.move(e, l.root.prev)
+}
+
+// Moves the element right before the mark element
+func (l *List[T]) MoveBefore(e, mark *Element[T]) {
+	if e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark.prev)
+}
+
+// Moves the element right after the mark element
+func (l *List[T]) MoveAfter(e, mark *Element[T]) {
+	if e.list != l || mark.list != l || e == mark {
+		return
+	}
+	l.move(e, mark)
+}
+
+// Removes all elements from the list
+func (l *List[T]) Clear() *List[T] {
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		e.next = nil
+		e.prev = nil
+		e.list = nil
+		e = next
+	}
+	l.init()
+	return l
+}
+
+// Creates a new array with the values of the list, in order
+func (l *List[T]) ToArray() *Array[T] {
+	arr := make([]T, 0, l.length)
+	for e := l.Front(); e != nil; e = e.Next() {
+		arr = append(arr, e.Value)
+	}
+	return NewArray(arr)
+}
+
+// Creates a new slice with the values of the list, in order
+func (l *List[T]) ToSlice() []T {
+	return l.ToArray().data
+}
+
+func (l *List[T]) Next() (T, bool) {
+	l.lazyInit()
+	if l.iterNext == nil {
+		l.iterNext = l.root.next
+	}
+	if l.iterNext != &l.root {
+		retVal := l.iterNext.Value
+		l.iterNext = l.iterNext.next
+		return retVal, false
+	}
+	var zero T
+	return zero, true
+}
+
+func (l *List[T]) IterReset() {
+	l.iterNext = nil
+}
+
+// Returns an iterator going over the values from the front to the
+// back of the list
+func (l *List[T]) Iter() func(func(T) bool) {
+	return Iterator(l)
+}
+
+// Returns an iterator going over the values from the back to the
+// front of the list
+func (l *List[T]) IterBackward() func(func(T) bool) {
+	return Iterator(&listBackwardIterable[T]{list: l})
+}
+
+// Returns an iterator going over the element handles from the front
+// to the back of the list. It is safe to remove the yielded element
+// from the list during the iteration.
+func (l *List[T]) Elements() func(func(*Element[T]) bool) {
+	return func(yield func(*Element[T]) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+type listBackwardIterable[T any] struct {
+	list *List[T]
+	next *Element[T]
+}
+
+func (it *listBackwardIterable[T]) Next() (T, bool) {
+	it.list.lazyInit()
+	if it.next == nil {
+		it.next = it.list.root.prev
+	}
+	if it.next != &it.list.root {
+		retVal := it.next.Value
+		it.next = it.next.prev
+		return retVal, false
+	}
+	var zero T
+	return zero, true
+}
+
+func (it *listBackwardIterable[T]) IterReset() {
+	it.next = nil
+}
@@ -0,0 +1,181 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListPush(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]int{3, 4})
+	l.PushBack(5)
+	l.PushFront(2)
+	l.PushFront(1)
+
+	assert.Equal(5, l.Length())
+	assert.Equal(1, l.Front().Value)
+	assert.Equal(5, l.Back().Value)
+	assert.Equal(
+		[]int{1, 2, 3, 4, 5},
+		l.ToSlice(),
+	)
+}
+
+func TestListZeroValue(t *testing.T) {
+	assert := assert.New(t)
+
+	var l List[string]
+
+	assert.Nil(l.Front())
+	assert.Nil(l.Back())
+
+	l.PushBack("foo")
+	l.PushFront("bar")
+
+	assert.Equal(
+		[]string{"bar", "foo"},
+		l.ToSlice(),
+	)
+}
+
+func TestListInsertBeforeAndAfter(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]int{})
+	mid := l.PushBack(3)
+	l.InsertBefore(1, mid)
+	l.InsertBefore(2, mid)
+	l.InsertAfter(5, mid)
+	l.InsertAfter(4, mid)
+
+	assert.Equal(
+		[]int{1, 2, 3, 4, 5},
+		l.ToSlice(),
+	)
+
+	other := NewList([]int{})
+	assert.Nil(other.InsertAfter(10, mid))
+	assert.Equal(0, other.Length())
+}
+
+func TestListRemove(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]string{})
+	l.PushBack("foo")
+	bar := l.PushBack("bar")
+	l.PushBack("baz")
+
+	assert.Equal("bar", l.Remove(bar))
+	assert.Equal(2, l.Length())
+	assert.Nil(bar.Next())
+	assert.Nil(bar.Prev())
+	assert.Equal(
+		[]string{"foo", "baz"},
+		l.ToSlice(),
+	)
+
+	// removing an element twice is a no-op
+	l.Remove(bar)
+	assert.Equal(2, l.Length())
+}
+
+func TestListMove(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]int{})
+	e1 := l.PushBack(1)
+	e2 := l.PushBack(2)
+	e3 := l.PushBack(3)
+
+	l.MoveToFront(e3)
+	assert.Equal([]int{3, 1, 2}, l.ToSlice())
+
+	l.MoveToBack(e3)
+	assert.Equal([]int{1, 2, 3}, l.ToSlice())
+
+	l.MoveBefore(e3, e1)
+	assert.Equal([]int{3, 1, 2}, l.ToSlice())
+
+	l.MoveAfter(e3, e2)
+	assert.Equal([]int{1, 2, 3}, l.ToSlice())
+
+	l.MoveToFront(e1)
+	assert.Equal([]int{1, 2, 3}, l.ToSlice())
+	assert.Equal(3, l.Length())
+}
+
+func TestListElementNavigation(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]int{1, 2, 3})
+
+	acc := []int{}
+	for e := l.Back(); e != nil; e = e.Prev() {
+		acc = append(acc, e.Value)
+	}
+
+	assert.Equal([]int{3, 2, 1}, acc)
+}
+
+func TestListIterators(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]int{1, 2, 3, 4})
+
+	forward := []int{}
+	for v := range l.Iter() {
+		forward = append(forward, v)
+	}
+
+	backward := []int{}
+	for v := range l.IterBackward() {
+		backward = append(backward, v)
+		if v == 2 {
+			break
+		}
+	}
+
+	assert.Equal([]int{1, 2, 3, 4}, forward)
+	assert.Equal([]int{4, 3, 2}, backward)
+}
+
+func TestListRemoveWhileIterating(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]int{1, 2, 3, 4, 5})
+
+	for e := range l.Elements() {
+		if e.Value%2 == 0 {
+			l.Remove(e)
+		}
+	}
+
+	assert.Equal([]int{1, 3, 5}, l.ToSlice())
+}
+
+func TestListToArray(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]string{"foo", "bar"})
+	arr := l.ToArray()
+	arr.Push("baz")
+
+	assert.Equal([]string{"foo", "bar", "baz"}, arr.ToSlice())
+	assert.Equal(2, l.Length())
+}
+
+func TestListClear(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewList([]int{1, 2, 3})
+	e := l.Front()
+	l.Clear()
+
+	assert.Equal(0, l.Length())
+	assert.Nil(l.Front())
+	assert.Nil(e.Next())
+}
@@ -0,0 +1,226 @@
+package ezs
+
+import "sync"
+
+// Least-recently-used cache. Entries are kept in a List ordered from
+// the least to the most recently used key, once the capacity is
+// exceeded the least recently used entry is evicted. All operations
+// are O(1).
+type LRUCache[K comparable, V any] struct {
+	items    map[K]*Element[*MapEntry[K, V]]
+	order    *List[*MapEntry[K, V]]
+	capacity int
+	onEvict  func(key K, value V)
+	stats    CacheStats
+	mu       sync.Locker
+}
+
+// Creates a new LRU cache holding at most {capacity} entries. A
+// capacity of zero or less means the cache is never evicted.
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		items:    make(map[K]*Element[*MapEntry[K, V]]),
+		order:    NewList([]*MapEntry[K, V]{}),
+		capacity: capacity,
+		mu:       noopLocker{},
+	}
+}
+
+// Creates a new LRU cache that can be safely used from multiple
+// goroutines
+func NewSyncLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	c := NewLRUCache[K, V](capacity)
+	c.mu = &sync.Mutex{}
+	return c
+}
+
+// Sets the callback invoked with every entry evicted from the cache
+// due to the capacity limit
+func (c *LRUCache[K, V]) OnEvict(fn func(key K, value V)) *LRUCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = fn
+	return c
+}
+
+func (c *LRUCache[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*LRUCache[K, V])
+	from.mu.Lock()
+	defer from.mu.Unlock()
+
+	c.items = make(map[K]*Element[*MapEntry[K, V]], len(from.items))
+	c.order = NewList([]*MapEntry[K, V]{})
+	for e := from.order.Front(); e != nil; e = e.Next() {
+		entry := &MapEntry[K, V]{e.Value.Key, cloneValue(s, e.Value.Value)}
+		c.items[entry.Key] = c.order.PushBack(entry)
+	}
+	c.capacity = from.capacity
+	c.onEvict = from.onEvict
+	c.stats = from.stats
+	c.mu = newLockerLike(from.mu)
+}
+
+func (c *LRUCache[K, V]) evict() []*MapEntry[K, V] {
+	var evicted []*MapEntry[K, V]
+	for c.capacity > 0 && len(c.items) > c.capacity {
+		e := c.order.Remove(c.order.Front())
+		delete(c.items, e.Key)
+		c.stats.Evictions++
+		evicted = append(evicted, e)
+	}
+	return evicted
+}
+
+func (c *LRUCache[K, V]) notify(evicted []*MapEntry[K, V], onEvict func(key K, value V)) {
+	if onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		onEvict(e.Key, e.Value)
+	}
+}
+
+// Returns the value of the given key and marks it as the most
+// recently used one
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.order.MoveToBack(el)
+	return el.Value.Value, true
+}
+
+// Returns the value of the given key without changing its recency
+// or the cache statistics
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		return el.Value.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Returns true if the key is in the cache, without changing its
+// recency
+func (c *LRUCache[K, V]) Has(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.items[key]
+	return ok
+}
+
+// Adds or updates the entry, marks it as the most recently used one
+// and evicts the least recently used entries above the capacity
+func (c *LRUCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		el.Value.Value = value
+		c.order.MoveToBack(el)
+	} else {
+		c.items[key] = c.order.PushBack(&MapEntry[K, V]{key, value})
+	}
+	evicted := c.evict()
+	onEvict := c.onEvict
+	c.mu.Unlock()
+	c.notify(evicted, onEvict)
+}
+
+// Removes the entry from the cache, returns false if there was no
+// such entry. The eviction callback is not called.
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.Remove(el)
+	delete(c.items, key)
+	return true
+}
+
+// Removes all entries from the cache, without calling the eviction
+// callback
+func (c *LRUCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[K]*Element[*MapEntry[K, V]])
+	c.order.Clear()
+}
+
+// Returns the number of entries in the cache
+func (c *LRUCache[K, V]) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Returns the maximum number of entries in the cache
+func (c *LRUCache[K, V]) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capacity
+}
+
+// Changes the capacity of the cache, evicting the least recently
+// used entries if needed
+func (c *LRUCache[K, V]) Resize(capacity int) {
+	c.mu.Lock()
+	c.capacity = capacity
+	evicted := c.evict()
+	onEvict := c.onEvict
+	c.mu.Unlock()
+	c.notify(evicted, onEvict)
+}
+
+// Returns the hit, miss and eviction counters
+func (c *LRUCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Sets all the statistics counters back to zero
+func (c *LRUCache[K, V]) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = CacheStats{}
+}
+
+// Returns the keys ordered from the most to the least recently used
+func (c *LRUCache[K, V]) Keys() *Array[K] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, len(c.items))
+	for e := range c.order.IterBackward() {
+		keys = append(keys, e.Key)
+	}
+	return NewArray(keys)
+}
+
+// Returns the entries ordered from the most to the least recently
+// used
+func (c *LRUCache[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := make([]*MapEntry[K, V], 0, len(c.items))
+	for e := range c.order.IterBackward() {
+		entries = append(entries, &MapEntry[K, V]{e.Key, e.Value})
+	}
+	return NewArray(entries)
+}
+
+// Returns an iterator over a snapshot of the entries, ordered from
+// the most to the least recently used. Iterating does not change the
+// recency of the entries.
+func (c *LRUCache[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return c.Entries().Iter()
+}
@@ -0,0 +1,220 @@
+package ezs_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCacheSetAndGet(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLRUCache[string, int](2)
+	c.Set("one", 1)
+	c.Set("two", 2)
+
+	v, ok := c.Get("one")
+	assert.True(ok)
+	assert.Equal(1, v)
+
+	c.Set("three", 3)
+
+	_, ok = c.Get("two")
+	assert.False(ok)
+	assert.Equal(2, c.Count())
+	assert.Equal(
+		[]string{"three", "one"},
+		c.Keys().ToSlice(),
+	)
+}
+
+func TestLRUCachePeek(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLRUCache[string, int](2)
+	c.Set("one", 1)
+	c.Set("two", 2)
+
+	v, ok := c.Peek("one")
+	assert.True(ok)
+	assert.Equal(1, v)
+
+	c.Set("three", 3)
+
+	assert.False(c.Has("one"))
+	assert.True(c.Has("two"))
+	assert.Equal(CacheStats{Evictions: 1}, c.Stats())
+}
+
+func TestLRUCacheUpdatePromotes(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLRUCache[string, int](2)
+	c.Set("one", 1)
+	c.Set("two", 2)
+	c.Set("one", 10)
+	c.Set("three", 3)
+
+	v, _ := c.Peek("one")
+	assert.Equal(10, v)
+	assert.False(c.Has("two"))
+}
+
+func TestLRUCacheDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	evicted := 0
+	c := NewLRUCache[string, int](3).OnEvict(func(string, int) {
+		evicted++
+	})
+	c.Set("one", 1)
+	c.Set("two", 2)
+
+	assert.True(c.Delete("one"))
+	assert.False(c.Delete("one"))
+	assert.Equal(1, c.Count())
+	assert.Equal(0, evicted)
+}
+
+func TestLRUCacheOnEvict(t *testing.T) {
+	assert := assert.New(t)
+
+	evicted := NewMap(map[string]int{})
+	c := NewLRUCache[string, int](2).OnEvict(func(key string, value int) {
+		evicted.Set(key, value)
+	})
+
+	for i := 0; i < 5; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	assert.Equal(
+		[]string{"0", "1", "2"},
+		evicted.Keys().ToSlice(),
+	)
+
+	c.Resize(1)
+
+	assert.Equal(
+		[]string{"0", "1", "2", "3"},
+		evicted.Keys().ToSlice(),
+	)
+	assert.Equal(1, c.Capacity())
+	assert.Equal(uint64(4), c.Stats().Evictions)
+}
+
+func TestLRUCacheStats(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLRUCache[string, int](2)
+	c.Set("one", 1)
+	c.Get("one")
+	c.Get("one")
+	c.Get("two")
+
+	stats := c.Stats()
+	assert.Equal(uint64(2), stats.Hits)
+	assert.Equal(uint64(1), stats.Misses)
+	assert.InDelta(2.0/3.0, stats.HitRate(), 0.0001)
+
+	c.ResetStats()
+	assert.Equal(CacheStats{}, c.Stats())
+	assert.Equal(0.0, c.Stats().HitRate())
+}
+
+func TestLRUCacheIter(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLRUCache[string, int](0)
+	c.Set("one", 1)
+	c.Set("two", 2)
+	c.Set("three", 3)
+	c.Get("one")
+
+	iteratedOver := []*MapEntry[string, int]{}
+	for e := range c.Iter() {
+		iteratedOver = append(iteratedOver, e)
+	}
+
+	assert.Equal(
+		[]*MapEntry[string, int]{
+			{"one", 1},
+			{"three", 3},
+			{"two", 2},
+		},
+		iteratedOver,
+	)
+}
+
+func TestSyncLRUCache(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewSyncLRUCache[int, int](50)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				c.Set(g*100+i, i)
+				c.Get(g*100 + i/2)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	assert.Equal(50, c.Count())
+	assert.Equal(uint64(750), c.Stats().Evictions)
+}
+
+func TestLRUCacheLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	const size = 100_000
+	c := NewLRUCache[int, int](size)
+	for i := 0; i < size; i++ {
+		c.Set(i, i)
+	}
+
+	// Hits and updates are O(1), with a linear scan of the recency
+	// order this would take several seconds
+	start := time.Now()
+	for i := 0; i < size; i++ {
+		c.Get(i)
+		c.Set(size-1-i, i)
+	}
+	assert.Less(time.Since(start), 3*time.Second)
+
+	c.Set(size, size)
+	assert.Equal(size, c.Count())
+	// Touched last by the Get of the middle iteration
+	assert.False(c.Has(size / 2))
+	assert.True(c.Has(size/2 - 1))
+}
+
+func BenchmarkLRUCacheGet(b *testing.B) {
+	const size = 100_000
+	c := NewLRUCache[int, int](size)
+	for i := 0; i < size; i++ {
+		c.Set(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Get(i % size)
+	}
+}
+
+func BenchmarkLRUCacheSet(b *testing.B) {
+	const size = 100_000
+	c := NewLRUCache[int, int](size)
+
+	for i := 0; i < b.N; i++ {
+		c.Set(i%(2*size), i)
+	}
+}
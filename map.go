@@ -1,9 +1,15 @@
 package ezs
 
+import (
+	"fmt"
+	"slices"
+)
+
 type Map[K comparable, V any] struct {
 	inner   map[K]V
 	keys    []K
 	iterIdx int
+	frozen  bool
 }
 
 func NewMap[K comparable, V any](inner map[K]V) *Map[K, V] {
@@ -41,6 +47,30 @@ func (m *Map[K, V]) removekey(key K) {
 	}
 }
 
+// Makes the map read-only, any later call to a method modifying the
+// map panics with ErrFrozen
+func (m *Map[K, V]) Freeze() *Map[K, V] {
+	m.frozen = true
+	return m
+}
+
+// Returns true if the map has been frozen
+func (m *Map[K, V]) IsFrozen() bool {
+	return m.frozen
+}
+
+func (m *Map[K, V]) checkFrozen() {
+	if m.frozen {
+		panic(ErrFrozen)
+	}
+}
+
+// Returns a read-only view of the map. The view does not copy the
+// entries, changes made to the map are visible through it.
+func (m *Map[K, V]) ReadOnly() *ReadOnlyMap[K, V] {
+	return &ReadOnlyMap[K, V]{inner: m}
+}
+
 func (m *Map[K, V]) Has(key K) bool {
 	_, ok := m.inner[key]
 	return ok
@@ -52,17 +82,128 @@ func (m *Map[K, V]) Get(key K) (V, bool) {
 }
 
 func (m *Map[K, V]) Set(key K, value V) *Map[K, V] {
+	m.checkFrozen()
 	m.addkey(key)
 	m.inner[key] = value
 	return m
 }
 
 func (m *Map[K, V]) Delete(key K) *Map[K, V] {
+	m.checkFrozen()
 	m.removekey(key)
 	delete(m.inner, key)
 	return m
 }
 
+// Returns the value of the key, or the given default value if the map
+// has no such key
+func (m *Map[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if v, ok := m.inner[key]; ok {
+		return v
+	}
+	return defaultValue
+}
+
+// Returns the value of the key, inserting the given value first if the
+// map has no such key
+func (m *Map[K, V]) GetOrInsert(key K, value V) V {
+	if v, ok := m.inner[key]; ok {
+		return v
+	}
+	m.insert(key, value)
+	return value
+}
+
+// Returns the value of the key, inserting the value returned by the
+// function first if the map has no such key. The function is only
+// called when the key is missing.
+func (m *Map[K, V]) GetOrInsertWith(key K, fn func() V) V {
+	if v, ok := m.inner[key]; ok {
+		return v
+	}
+	value := fn()
+	m.insert(key, value)
+	return value
+}
+
+// Replaces the value of an existing key with the value returned by the
+// function, returns false if the map has no such key
+func (m *Map[K, V]) Update(key K, fn func(value V) V) bool {
+	v, ok := m.inner[key]
+	if !ok {
+		return false
+	}
+	m.checkFrozen()
+	m.inner[key] = fn(v)
+	return true
+}
+
+// Sets the key to the value returned by the function, which receives
+// the current value and whether the key exists. New keys are added at
+// the end of the key order, existing keys keep their position.
+func (m *Map[K, V]) Upsert(key K, fn func(old V, exists bool) V) V {
+	m.checkFrozen()
+	old, ok := m.inner[key]
+	value := fn(old, ok)
+	if ok {
+		m.inner[key] = value
+	} else {
+		m.insert(key, value)
+	}
+	return value
+}
+
+// Same as Upsert, but the entry is removed, or not inserted, if the
+// function returns false as the second value. Returns the new value of
+// the key and whether the key is in the map.
+func (m *Map[K, V]) Compute(key K, fn func(old V, exists bool) (V, bool)) (V, bool) {
+	m.checkFrozen()
+	old, ok := m.inner[key]
+	value, keep := fn(old, ok)
+	switch {
+	case keep && ok:
+		m.inner[key] = value
+	case keep:
+		m.insert(key, value)
+	case ok:
+		m.removekey(key)
+		delete(m.inner, key)
+	}
+	if !keep {
+		var zero V
+		return zero, false
+	}
+	return value, true
+}
+
+// Adds an entry for a key that is not in the map yet
+func (m *Map[K, V]) insert(key K, value V) {
+	m.checkFrozen()
+	m.keys = append(m.keys, key)
+	m.inner[key] = value
+}
+
+// Adds an entry for a key that is not in the map yet at the given
+// position of the key order, or at the end if the position is past it
+func (m *Map[K, V]) insertAt(idx int, key K, value V) {
+	m.checkFrozen()
+	m.keys = slices.Insert(m.keys, min(idx, len(m.keys)), key)
+	m.inner[key] = value
+}
+
+// Removes the entries for which the function returns true in a single
+// pass over the keys
+func (m *Map[K, V]) deleteFunc(fn func(key K, value V) bool) {
+	m.checkFrozen()
+	m.keys = slices.DeleteFunc(m.keys, func(k K) bool {
+		if fn(k, m.inner[k]) {
+			delete(m.inner, k)
+			return true
+		}
+		return false
+	})
+}
+
 func (m *Map[K, V]) Count() int {
 	return len(m.inner)
 }
@@ -136,11 +277,102 @@ func (m *Map[K, V]) FindKey(fn func(key K, value V) bool) (K, bool) {
 }
 
 func (m *Map[K, V]) Copy() *Map[K, V] {
-	newMap := make(map[K]V)
+	newMap := make(map[K]V, len(m.inner))
 	for k, v := range m.inner {
 		newMap[k] = v
 	}
-	return NewMap[K, V](newMap)
+	return &Map[K, V]{inner: newMap, keys: slices.Clone(m.keys)}
+}
+
+// Returns a new map with the entries of both maps, the values of the
+// other map replace the ones of this map. The keys of this map come
+// first, followed by the new keys in the order of the other map.
+func (m *Map[K, V]) Merge(other *Map[K, V]) *Map[K, V] {
+	return m.Copy().Assign(other)
+}
+
+// Same as Merge, but the value of a key present in both maps is the
+// one returned by the resolve function
+func (m *Map[K, V]) MergeWith(other *Map[K, V], resolve func(key K, a, b V) V) *Map[K, V] {
+	merged := m.Copy()
+	for _, k := range other.keys {
+		b := other.inner[k]
+		if a, ok := merged.inner[k]; ok {
+			merged.inner[k] = resolve(k, a, b)
+		} else {
+			merged.insert(k, b)
+		}
+	}
+	return merged
+}
+
+// Sets in place the entries of the other maps, in order, so the values
+// of the last map win
+func (m *Map[K, V]) Assign(others ...*Map[K, V]) *Map[K, V] {
+	m.checkFrozen()
+	for _, other := range others {
+		for _, k := range other.keys {
+			m.Set(k, other.inner[k])
+		}
+	}
+	return m
+}
+
+// Creates a deep copy of the map, see DeepClone for how the values
+// are copied. The order of the keys is preserved.
+func (m *Map[K, V]) DeepCopy(reflectStructs bool) *Map[K, V] {
+	return DeepClone(m, reflectStructs)
+}
+
+func (m *Map[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*Map[K, V])
+	m.inner = make(map[K]V, len(from.inner))
+	m.keys = slices.Clone(from.keys)
+	for k, v := range from.inner {
+		m.inner[k] = cloneValue(s, v)
+	}
+}
+
+// Creates a new map with the entries that satisfy the predicate
+func (m *Map[K, V]) Filter(fn func(key K, value V) bool) *Map[K, V] {
+	filtered, _ := m.Partition(fn)
+	return filtered
+}
+
+// Creates a new map with the entries that do not satisfy the predicate
+func (m *Map[K, V]) Reject(fn func(key K, value V) bool) *Map[K, V] {
+	_, rejected := m.Partition(fn)
+	return rejected
+}
+
+// Splits the map into two new maps, the first one with the entries
+// that satisfy the predicate and the second one with the rest
+func (m *Map[K, V]) Partition(fn func(key K, value V) bool) (*Map[K, V], *Map[K, V]) {
+	matching := NewMap(map[K]V{})
+	rest := NewMap(map[K]V{})
+	for _, k := range m.keys {
+		v := m.inner[k]
+		if fn(k, v) {
+			matching.insert(k, v)
+		} else {
+			rest.insert(k, v)
+		}
+	}
+	return matching, rest
+}
+
+// Creates a new map with only the given keys, missing keys are ignored
+func (m *Map[K, V]) Pick(keys ...K) *Map[K, V] {
+	return m.Filter(func(key K, _ V) bool {
+		return slices.Contains(keys, key)
+	})
+}
+
+// Creates a new map without the given keys
+func (m *Map[K, V]) Omit(keys ...K) *Map[K, V] {
+	return m.Reject(func(key K, _ V) bool {
+		return slices.Contains(keys, key)
+	})
 }
 
 func (m *Map[K, V]) Next() (*MapEntry[K, V], bool) {
@@ -164,3 +396,48 @@ func (m *Map[K, V]) IterReset() {
 func (m *Map[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
 	return Iterator(m)
 }
+
+// Creates a new map with the same keys and the values converted by the
+// mapper function
+func MapValues[K comparable, V any, U any](m *Map[K, V], mapper func(key K, value V) U) *Map[K, U] {
+	mapped := &Map[K, U]{inner: make(map[K]U, len(m.keys)), keys: slices.Clone(m.keys)}
+	for _, k := range m.keys {
+		mapped.inner[k] = mapper(k, m.inner[k])
+	}
+	return mapped
+}
+
+// Creates a new map with the keys converted by the mapper function.
+// When several keys are converted to the same key, the resolve function
+// receives the value kept so far and the colliding one and returns the
+// value to keep. If resolve is nil, a collision panics.
+func MapKeys[K comparable, V any, J comparable](
+	m *Map[K, V],
+	mapper func(key K, value V) J,
+	resolve func(key J, a, b V) V,
+) *Map[J, V] {
+	mapped := NewMap(map[J]V{})
+	for _, k := range m.keys {
+		v := m.inner[k]
+		newKey := mapper(k, v)
+		if existing, ok := mapped.inner[newKey]; ok {
+			if resolve == nil {
+				panic(fmt.Sprintf("ezs: MapKeys key collision on %v", newKey))
+			}
+			mapped.inner[newKey] = resolve(newKey, existing, v)
+		} else {
+			mapped.insert(newKey, v)
+		}
+	}
+	return mapped
+}
+
+// Creates a new map with the keys and values swapped. When several
+// keys have the same value, the last one of them wins.
+func Invert[K comparable, V comparable](m *Map[K, V]) *Map[V, K] {
+	inverted := NewMap(map[V]K{})
+	for _, k := range m.keys {
+		inverted.Set(m.inner[k], k)
+	}
+	return inverted
+}
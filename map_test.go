@@ -3,6 +3,8 @@ package ezs_test
 import (
 	"cmp"
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
 	. "github.com/ncpa0cpl/ezs"
@@ -374,3 +376,189 @@ func TestMapCopy(t *testing.T) {
 		m1.Count(),
 	)
 }
+
+func TestMapFreeze(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[string]int{"one": 1}).Freeze()
+
+	assert.True(m.IsFrozen())
+	assert.PanicsWithValue(ErrFrozen, func() { m.Set("two", 2) })
+	assert.PanicsWithValue(ErrFrozen, func() { m.Delete("one") })
+	assert.Equal(map[string]int{"one": 1}, m.ToMap())
+
+	copied := m.Copy()
+	copied.Set("two", 2)
+	assert.False(copied.IsFrozen())
+	assert.Equal(2, copied.Count())
+}
+
+func TestMapGetOrDefaultAndInsert(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[string]int{})
+	m.Set("a", 1)
+
+	assert.Equal(1, m.GetOrDefault("a", 10))
+	assert.Equal(10, m.GetOrDefault("b", 10))
+	assert.False(m.Has("b"))
+
+	assert.Equal(1, m.GetOrInsert("a", 10))
+	assert.Equal(2, m.GetOrInsert("b", 2))
+
+	calls := 0
+	fn := func() int {
+		calls++
+		return 3
+	}
+	assert.Equal(3, m.GetOrInsertWith("c", fn))
+	assert.Equal(3, m.GetOrInsertWith("c", fn))
+	assert.Equal(1, calls)
+
+	assert.Equal([]string{"a", "b", "c"}, m.Keys().ToSlice())
+}
+
+func TestMapUpdateAndUpsert(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[string]int{})
+	m.Set("a", 1).Set("b", 2)
+
+	double := func(v int) int { return v * 2 }
+	assert.True(m.Update("a", double))
+	assert.False(m.Update("z", double))
+	assert.False(m.Has("z"))
+
+	increment := func(old int, exists bool) int {
+		if !exists {
+			return 100
+		}
+		return old + 1
+	}
+	assert.Equal(3, m.Upsert("b", increment))
+	assert.Equal(100, m.Upsert("c", increment))
+
+	assert.Equal(map[string]int{"a": 2, "b": 3, "c": 100}, m.ToMap())
+	assert.Equal([]string{"a", "b", "c"}, m.Keys().ToSlice())
+}
+
+func TestMapCompute(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[string]int{})
+	m.Set("a", 1).Set("b", 2)
+
+	decrement := func(old int, exists bool) (int, bool) {
+		if !exists {
+			return 1, true
+		}
+		return old - 1, old > 1
+	}
+
+	v, ok := m.Compute("b", decrement)
+	assert.True(ok)
+	assert.Equal(1, v)
+
+	_, ok = m.Compute("a", decrement)
+	assert.False(ok)
+	assert.False(m.Has("a"))
+
+	v, ok = m.Compute("c", decrement)
+	assert.True(ok)
+	assert.Equal(1, v)
+
+	_, ok = m.Compute("z", func(int, bool) (int, bool) { return 0, false })
+	assert.False(ok)
+	assert.False(m.Has("z"))
+
+	assert.Equal([]string{"b", "c"}, m.Keys().ToSlice())
+
+	m.Freeze()
+	assert.Equal(1, m.GetOrInsert("b", 5))
+	assert.PanicsWithValue(ErrFrozen, func() { m.GetOrInsert("d", 5) })
+	assert.PanicsWithValue(ErrFrozen, func() { m.Compute("b", decrement) })
+}
+
+func TestMapMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	base := NewMap(map[string]int{})
+	base.Set("b", 1).Set("a", 2)
+	other := NewMap(map[string]int{})
+	other.Set("d", 30).Set("a", 20).Set("c", 40)
+
+	merged := base.Merge(other)
+	assert.Equal([]string{"b", "a", "d", "c"}, merged.Keys().ToSlice())
+	assert.Equal(map[string]int{"a": 20, "b": 1, "c": 40, "d": 30}, merged.ToMap())
+	assert.Equal(map[string]int{"a": 2, "b": 1}, base.ToMap())
+
+	summed := base.MergeWith(other, func(_ string, a, b int) int { return a + b })
+	assert.Equal(22, summed.GetOrDefault("a", 0))
+	assert.Equal([]string{"b", "a", "d", "c"}, summed.Keys().ToSlice())
+
+	last := NewMap(map[string]int{"a": 200})
+	base.Assign(other, last)
+	assert.Equal(map[string]int{"a": 200, "b": 1, "c": 40, "d": 30}, base.ToMap())
+	assert.Equal([]string{"b", "a", "d", "c"}, base.Keys().ToSlice())
+}
+
+func TestMapCopyKeepsOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[int]int{})
+	for i := 10; i > 0; i-- {
+		m.Set(i, i)
+	}
+
+	assert.Equal(m.Keys().ToSlice(), m.Copy().Keys().ToSlice())
+}
+
+func TestMapFilterAndPartition(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[string]int{})
+	m.Set("d", 4).Set("a", 1).Set("c", 3).Set("b", 2)
+	even := func(_ string, v int) bool { return v%2 == 0 }
+
+	assert.Equal([]string{"d", "b"}, m.Filter(even).Keys().ToSlice())
+	assert.Equal([]string{"a", "c"}, m.Reject(even).Keys().ToSlice())
+
+	matching, rest := m.Partition(even)
+	assert.Equal(map[string]int{"d": 4, "b": 2}, matching.ToMap())
+	assert.Equal(map[string]int{"a": 1, "c": 3}, rest.ToMap())
+
+	assert.Equal([]string{"d", "b"}, m.Pick("b", "d", "missing").Keys().ToSlice())
+	assert.Equal([]string{"a", "c"}, m.Omit("b", "d").Keys().ToSlice())
+	assert.Equal(4, m.Count())
+}
+
+func TestMapValuesAndKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[string]int{})
+	m.Set("b", 2).Set("A", 1).Set("a", 10)
+
+	labels := MapValues(m, func(k string, v int) string {
+		return k + "=" + strconv.Itoa(v)
+	})
+	assert.Equal([]string{"b", "A", "a"}, labels.Keys().ToSlice())
+	assert.Equal("A=1", labels.GetOrDefault("A", ""))
+
+	lower := func(k string, _ int) string { return strings.ToLower(k) }
+	summed := MapKeys(m, lower, func(_ string, a, b int) int { return a + b })
+	assert.Equal([]string{"b", "a"}, summed.Keys().ToSlice())
+	assert.Equal(11, summed.GetOrDefault("a", 0))
+
+	assert.Panics(func() { MapKeys(m, lower, nil) })
+}
+
+func TestInvert(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[string]int{})
+	m.Set("one", 1).Set("two", 2).Set("uno", 1)
+
+	inverted := Invert(m)
+	assert.Equal([]int{1, 2}, inverted.Keys().ToSlice())
+	assert.Equal(map[int]string{1: "uno", 2: "two"}, inverted.ToMap())
+}
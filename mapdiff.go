@@ -0,0 +1,127 @@
+package ezs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Old and new value of an entry changed between two maps
+type ValueChange[V any] struct {
+	Old V
+	New V
+}
+
+// Difference between two maps, as returned by DiffMaps. Removed,
+// Changed and Unchanged follow the key order of the old map, Added
+// the key order of the new one.
+type MapDiff[K comparable, V any] struct {
+	Added     *Map[K, V]
+	Removed   *Map[K, V]
+	Changed   *Map[K, ValueChange[V]]
+	Unchanged *Map[K, V]
+	// Positions of the added and removed keys in the new and old map
+	addedAt   map[K]int
+	removedAt map[K]int
+}
+
+// Compares the entries of the maps, using the eq function to compare
+// the values of keys present in both. If eq is nil, the values are
+// compared with reflect.DeepEqual.
+func DiffMaps[K comparable, V any](a, b *Map[K, V], eq func(V, V) bool) *MapDiff[K, V] {
+	if eq == nil {
+		eq = func(x, y V) bool {
+			return reflect.DeepEqual(x, y)
+		}
+	}
+
+	diff := &MapDiff[K, V]{
+		Added:     NewMap(map[K]V{}),
+		Removed:   NewMap(map[K]V{}),
+		Changed:   NewMap(map[K]ValueChange[V]{}),
+		Unchanged: NewMap(map[K]V{}),
+		addedAt:   make(map[K]int),
+		removedAt: make(map[K]int),
+	}
+	for i, k := range a.keys {
+		old := a.inner[k]
+		v, ok := b.inner[k]
+		switch {
+		case !ok:
+			diff.Removed.insert(k, old)
+			diff.removedAt[k] = i
+		case eq(old, v):
+			diff.Unchanged.insert(k, old)
+		default:
+			diff.Changed.insert(k, ValueChange[V]{old, v})
+		}
+	}
+	for i, k := range b.keys {
+		if _, ok := a.inner[k]; !ok {
+			diff.Added.insert(k, b.inner[k])
+			diff.addedAt[k] = i
+		}
+	}
+	return diff
+}
+
+// Returns true if the maps have the same entries
+func (d *MapDiff[K, V]) IsEmpty() bool {
+	return d.Added.Count() == 0 && d.Removed.Count() == 0 && d.Changed.Count() == 0
+}
+
+// Returns the diff going from the new map to the old one
+func (d *MapDiff[K, V]) Reverse() *MapDiff[K, V] {
+	return &MapDiff[K, V]{
+		Added:   d.Removed,
+		Removed: d.Added,
+		Changed: MapValues(d.Changed, func(_ K, c ValueChange[V]) ValueChange[V] {
+			return ValueChange[V]{c.New, c.Old}
+		}),
+		Unchanged: d.Unchanged,
+		addedAt:   d.removedAt,
+		removedAt: d.addedAt,
+	}
+}
+
+// Patches the map in place, removing, changing and adding the entries
+// of the diff. Use the Reverse of the diff to undo it. Added keys are
+// inserted at their position in the new map, so the key order is
+// restored as long as the keys present in both maps are in the same
+// order in each.
+func (d *MapDiff[K, V]) Apply(m *Map[K, V]) *Map[K, V] {
+	for _, k := range d.Removed.keys {
+		m.Delete(k)
+	}
+	for _, k := range d.Changed.keys {
+		m.Set(k, d.Changed.inner[k].New)
+	}
+	// Added keys follow the key order of the new map, so each one is
+	// inserted after the keys preceding it there
+	for _, k := range d.Added.keys {
+		if idx, ok := d.addedAt[k]; ok && !m.Has(k) {
+			m.insertAt(idx, k, d.Added.inner[k])
+		} else {
+			m.Set(k, d.Added.inner[k])
+		}
+	}
+	return m
+}
+
+// Renders the diff one entry per line, prefixing removed entries with
+// "-", changed ones with "~" and added ones with "+". Unchanged
+// entries are left out.
+func (d *MapDiff[K, V]) String() string {
+	var sb strings.Builder
+	for _, k := range d.Removed.keys {
+		fmt.Fprintf(&sb, "- %v: %v\n", k, d.Removed.inner[k])
+	}
+	for _, k := range d.Changed.keys {
+		c := d.Changed.inner[k]
+		fmt.Fprintf(&sb, "~ %v: %v -> %v\n", k, c.Old, c.New)
+	}
+	for _, k := range d.Added.keys {
+		fmt.Fprintf(&sb, "+ %v: %v\n", k, d.Added.inner[k])
+	}
+	return sb.String()
+}
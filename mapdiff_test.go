@@ -0,0 +1,89 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func diffFixture() (*Map[string, int], *Map[string, int]) {
+	a := NewMap(map[string]int{})
+	a.Set("port", 80).Set("workers", 4).Set("debug", 0)
+	b := NewMap(map[string]int{})
+	b.Set("timeout", 30).Set("port", 8080).Set("workers", 4).Set("retries", 3)
+	return a, b
+}
+
+func TestDiffMaps(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := diffFixture()
+	diff := DiffMaps(a, b, func(x, y int) bool { return x == y })
+
+	assert.Equal([]string{"timeout", "retries"}, diff.Added.Keys().ToSlice())
+	assert.Equal(map[string]int{"debug": 0}, diff.Removed.ToMap())
+	assert.Equal(
+		map[string]ValueChange[int]{"port": {Old: 80, New: 8080}},
+		diff.Changed.ToMap(),
+	)
+	assert.Equal(map[string]int{"workers": 4}, diff.Unchanged.ToMap())
+	assert.False(diff.IsEmpty())
+
+	assert.True(DiffMaps(a, a.Copy(), nil).IsEmpty())
+}
+
+func TestMapDiffString(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := diffFixture()
+	diff := DiffMaps(a, b, nil)
+
+	assert.Equal(
+		"- debug: 0\n"+
+			"~ port: 80 -> 8080\n"+
+			"+ timeout: 30\n"+
+			"+ retries: 3\n",
+		diff.String(),
+	)
+	assert.Equal("", DiffMaps(a, a, nil).String())
+}
+
+func TestMapApplyDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := diffFixture()
+	diff := DiffMaps(a, b, nil)
+
+	patched := diff.Apply(a.Copy())
+	assert.Equal(b.ToMap(), patched.ToMap())
+
+	restored := diff.Reverse().Apply(patched)
+	assert.Equal(a.ToMap(), restored.ToMap())
+	assert.Equal(
+		map[string]ValueChange[int]{"port": {Old: 8080, New: 80}},
+		diff.Reverse().Changed.ToMap(),
+	)
+}
+
+func TestMapApplyDiffKeepsKeyOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := diffFixture()
+	diff := DiffMaps(a, b, nil)
+
+	patched := diff.Apply(a.Copy())
+	assert.Equal([]string{"timeout", "port", "workers", "retries"}, patched.Keys().ToSlice())
+	restored := diff.Reverse().Apply(patched)
+	assert.Equal([]string{"port", "workers", "debug"}, restored.Keys().ToSlice())
+
+	a = NewMap(map[string]int{})
+	a.Set("a", 1).Set("b", 2).Set("c", 3)
+	b = NewMap(map[string]int{})
+	b.Set("b", 2).Set("c", 3)
+	diff = DiffMaps(a, b, nil)
+	assert.Equal(
+		[]string{"a", "b", "c"},
+		diff.Reverse().Apply(diff.Apply(a.Copy())).Keys().ToSlice(),
+	)
+}
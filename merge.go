@@ -0,0 +1,102 @@
+package ezs
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Decides how DeepMerge combines arrays found under the same key
+type ArrayMergePolicy int
+
+const (
+	// The array of the other map replaces the array of the base map
+	ReplaceArrays ArrayMergePolicy = iota
+	// The arrays are concatenated, base elements first
+	ConcatArrays
+)
+
+// Merges two configuration-like trees. Values found under the same key
+// in both maps are merged recursively if both are maps, either
+// *Map[string, any] or map[string]any, combined according to the
+// policy if both are slices or *Array of the same type, and
+// otherwise the value of the other map wins. The keys of the base map
+// come first, followed by the new keys in the order of the other map,
+// the keys of plain Go maps are sorted. Neither map is modified, but
+// values present in only one of them are not copied.
+func DeepMerge(base, other *Map[string, any], policy ArrayMergePolicy) *Map[string, any] {
+	merged := base.Copy()
+	for _, k := range other.keys {
+		b := other.inner[k]
+		if a, ok := merged.inner[k]; ok {
+			merged.inner[k] = deepMergeValues(a, b, policy)
+		} else {
+			merged.insert(k, b)
+		}
+	}
+	return merged
+}
+
+// Implemented by Array for every element type, so that arrays can be
+// concatenated without knowing their element type
+type anyConcatenator interface {
+	concatAny(other any) (any, bool)
+}
+
+// Returns a new array with the elements of both arrays, or false if
+// the other value is not an array of the same type
+func (a *Array[T]) concatAny(other any) (any, bool) {
+	b, ok := other.(*Array[T])
+	if !ok {
+		return nil, false
+	}
+	return NewArray(slices.Concat(a.data, b.data)), true
+}
+
+// Converts a plain map to a Map with sorted keys
+func mapWithSortedKeys(m map[string]any) *Map[string, any] {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return &Map[string, any]{inner: m, keys: keys}
+}
+
+func deepMergeValues(a, b any, policy ArrayMergePolicy) any {
+	switch a := a.(type) {
+	case *Map[string, any]:
+		switch b := b.(type) {
+		case *Map[string, any]:
+			return DeepMerge(a, b, policy)
+		case map[string]any:
+			return DeepMerge(a, mapWithSortedKeys(b), policy)
+		}
+		return b
+
+	case map[string]any:
+		switch b := b.(type) {
+		case *Map[string, any]:
+			return DeepMerge(mapWithSortedKeys(a), b, policy).ToMap()
+		case map[string]any:
+			return DeepMerge(mapWithSortedKeys(a), mapWithSortedKeys(b), policy).ToMap()
+		}
+		return b
+
+	case anyConcatenator:
+		if policy == ConcatArrays {
+			if merged, ok := a.concatAny(b); ok {
+				return merged
+			}
+		}
+		return b
+	}
+
+	if policy == ConcatArrays {
+		va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+		if va.Kind() == reflect.Slice && vb.Kind() == reflect.Slice && va.Type() == vb.Type() {
+			merged := reflect.MakeSlice(va.Type(), 0, va.Len()+vb.Len())
+			return reflect.AppendSlice(reflect.AppendSlice(merged, va), vb).Interface()
+		}
+	}
+	return b
+}
@@ -0,0 +1,104 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func orderedMap(entries ...any) *Map[string, any] {
+	m := NewMap(map[string]any{})
+	for i := 0; i < len(entries); i += 2 {
+		m.Set(entries[i].(string), entries[i+1])
+	}
+	return m
+}
+
+func TestDeepMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	base := orderedMap(
+		"name", "app",
+		"server", orderedMap("port", 80, "host", "localhost"),
+		"tags", []string{"a"},
+		"plugins", NewArray([]any{"x"}),
+		"limits", map[string]any{"cpu": 1, "memory": 512},
+	)
+	other := orderedMap(
+		"server", orderedMap("port", 8080, "tls", true),
+		"tags", []string{"b"},
+		"plugins", NewArray([]any{"y"}),
+		"limits", map[string]any{"memory": 1024},
+		"debug", true,
+	)
+
+	merged := DeepMerge(base, other, ConcatArrays)
+
+	assert.Equal(
+		[]string{"name", "server", "tags", "plugins", "limits", "debug"},
+		merged.Keys().ToSlice(),
+	)
+	server, _ := merged.Get("server")
+	assert.Equal([]string{"port", "host", "tls"}, server.(*Map[string, any]).Keys().ToSlice())
+	assert.Equal(8080, server.(*Map[string, any]).GetOrDefault("port", nil))
+
+	tags, _ := merged.Get("tags")
+	assert.Equal([]string{"a", "b"}, tags)
+	plugins, _ := merged.Get("plugins")
+	assert.Equal([]any{"x", "y"}, plugins.(*Array[any]).ToSlice())
+	limits, _ := merged.Get("limits")
+	assert.Equal(map[string]any{"cpu": 1, "memory": 1024}, limits)
+
+	baseServer, _ := base.Get("server")
+	assert.Equal(80, baseServer.(*Map[string, any]).GetOrDefault("port", nil))
+	baseTags, _ := base.Get("tags")
+	assert.Equal([]string{"a"}, baseTags)
+
+	replaced := DeepMerge(base, other, ReplaceArrays)
+	tags, _ = replaced.Get("tags")
+	assert.Equal([]string{"b"}, tags)
+	plugins, _ = replaced.Get("plugins")
+	assert.Equal([]any{"y"}, plugins.(*Array[any]).ToSlice())
+}
+
+func TestDeepMergeMixedTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	base := orderedMap(
+		"server", orderedMap("port", 80),
+		"value", orderedMap("nested", true),
+		"list", []int{1},
+	)
+	other := orderedMap(
+		"server", map[string]any{"tls": true, "host": "example.com"},
+		"value", "scalar",
+		"list", []string{"a"},
+	)
+
+	merged := DeepMerge(base, other, ConcatArrays)
+
+	server, _ := merged.Get("server")
+	assert.Equal([]string{"port", "host", "tls"}, server.(*Map[string, any]).Keys().ToSlice())
+	value, _ := merged.Get("value")
+	assert.Equal("scalar", value)
+	list, _ := merged.Get("list")
+	assert.Equal([]string{"a"}, list)
+}
+
+func TestDeepMergeTypedArrays(t *testing.T) {
+	assert := assert.New(t)
+
+	base := orderedMap("tags", NewArray([]string{"a"}), "ports", NewArray([]int{80}))
+	other := orderedMap("tags", NewArray([]string{"b"}), "ports", NewArray([]string{"http"}))
+
+	merged := DeepMerge(base, other, ConcatArrays)
+	tags, _ := merged.Get("tags")
+	assert.Equal([]string{"a", "b"}, tags.(*Array[string]).ToSlice())
+	ports, _ := merged.Get("ports")
+	assert.Equal([]string{"http"}, ports.(*Array[string]).ToSlice())
+
+	replaced := DeepMerge(base, other, ReplaceArrays)
+	tags, _ = replaced.Get("tags")
+	assert.Equal([]string{"b"}, tags.(*Array[string]).ToSlice())
+}
@@ -0,0 +1,225 @@
+package ezs
+
+type multiMapBucket[V comparable] struct {
+	values *Array[V]
+	// Only used by set-valued maps
+	index map[V]struct{}
+}
+
+// Map associating each key with multiple values. Keys are kept in the
+// order of insertion and the values of a key in the order they were
+// added. A key is removed once its last value is removed.
+type MultiMap[K comparable, V comparable] struct {
+	buckets      *Map[K, *multiMapBucket[V]]
+	unique       bool
+	valueCount   int
+	iterKeyIdx   int
+	iterValueIdx int
+}
+
+// Creates a list-valued multimap, a key can hold the same value
+// multiple times
+func NewMultiMap[K comparable, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{buckets: NewMap(map[K]*multiMapBucket[V]{})}
+}
+
+// Creates a set-valued multimap, adding a value the key already holds
+// does nothing
+func NewSetMultiMap[K comparable, V comparable]() *MultiMap[K, V] {
+	m := NewMultiMap[K, V]()
+	m.unique = true
+	return m
+}
+
+// Adds the value to the values of the key
+func (m *MultiMap[K, V]) Add(key K, value V) *MultiMap[K, V] {
+	b, ok := m.buckets.Get(key)
+	if !ok {
+		b = &multiMapBucket[V]{values: NewArray([]V{})}
+		if m.unique {
+			b.index = make(map[V]struct{})
+		}
+		m.buckets.insert(key, b)
+	}
+	if m.unique {
+		if _, ok := b.index[value]; ok {
+			return m
+		}
+		b.index[value] = struct{}{}
+	}
+	b.values.Push(value)
+	m.valueCount++
+	return m
+}
+
+// Adds all the values to the values of the key
+func (m *MultiMap[K, V]) AddAll(key K, values ...V) *MultiMap[K, V] {
+	for _, v := range values {
+		m.Add(key, v)
+	}
+	return m
+}
+
+// Returns a new array with the values of the key, the array is empty
+// if there is no such key
+func (m *MultiMap[K, V]) Get(key K) *Array[V] {
+	if b, ok := m.buckets.Get(key); ok {
+		return b.values.Copy()
+	}
+	return NewArray([]V{})
+}
+
+func (m *MultiMap[K, V]) Has(key K) bool {
+	return m.buckets.Has(key)
+}
+
+// Returns true if the key holds the given value
+func (m *MultiMap[K, V]) HasValue(key K, value V) bool {
+	b, ok := m.buckets.Get(key)
+	if !ok {
+		return false
+	}
+	if m.unique {
+		_, ok = b.index[value]
+		return ok
+	}
+	return Contains(b.values, value)
+}
+
+// Removes the first occurrence of the value from the values of the
+// key, returns false if the key does not hold the value
+func (m *MultiMap[K, V]) Remove(key K, value V) bool {
+	b, ok := m.buckets.Get(key)
+	if !ok {
+		return false
+	}
+	idx := IndexOf(b.values, value)
+	if idx == -1 {
+		return false
+	}
+	b.values.Splice(idx, 1)
+	delete(b.index, value)
+	m.valueCount--
+	if b.values.Length() == 0 {
+		m.buckets.Delete(key)
+	}
+	return true
+}
+
+// Removes the key with all its values and returns the removed values
+func (m *MultiMap[K, V]) RemoveAll(key K) *Array[V] {
+	b, ok := m.buckets.Get(key)
+	if !ok {
+		return NewArray([]V{})
+	}
+	m.buckets.Delete(key)
+	m.valueCount -= b.values.Length()
+	return b.values
+}
+
+// Returns the number of keys
+func (m *MultiMap[K, V]) Count() int {
+	return m.buckets.Count()
+}
+
+// Returns the number of values of all the keys
+func (m *MultiMap[K, V]) CountValues() int {
+	return m.valueCount
+}
+
+func (m *MultiMap[K, V]) Keys() *Array[K] {
+	return m.buckets.Keys()
+}
+
+// Returns the values of all the keys, in the order of the keys
+func (m *MultiMap[K, V]) Values() *Array[V] {
+	values := make([]V, 0, m.valueCount)
+	for _, k := range m.buckets.keys {
+		values = append(values, m.buckets.inner[k].values.data...)
+	}
+	return NewArray(values)
+}
+
+// Returns an entry for every value, in the order of the keys
+func (m *MultiMap[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	entries := make([]*MapEntry[K, V], 0, m.valueCount)
+	m.ForEach(func(k K, v V) {
+		entries = append(entries, &MapEntry[K, V]{k, v})
+	})
+	return NewArray(entries)
+}
+
+// Calls the function for every value, in the order of the keys
+func (m *MultiMap[K, V]) ForEach(fn func(key K, value V)) {
+	for _, k := range m.buckets.keys {
+		for _, v := range m.buckets.inner[k].values.data {
+			fn(k, v)
+		}
+	}
+}
+
+// Creates a map of the keys to new arrays of their values
+func (m *MultiMap[K, V]) ToMap() map[K]*Array[V] {
+	newMap := make(map[K]*Array[V], m.buckets.Count())
+	for _, k := range m.buckets.keys {
+		newMap[k] = m.buckets.inner[k].values.Copy()
+	}
+	return newMap
+}
+
+// Creates a shallow copy of the multimap
+func (m *MultiMap[K, V]) Copy() *MultiMap[K, V] {
+	c := NewMultiMap[K, V]()
+	c.unique = m.unique
+	m.ForEach(func(k K, v V) {
+		c.Add(k, v)
+	})
+	return c
+}
+
+func (m *MultiMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*MultiMap[K, V])
+	m.buckets = NewMap(map[K]*multiMapBucket[V]{})
+	m.unique = from.unique
+	m.valueCount = from.valueCount
+	for _, k := range from.buckets.keys {
+		values := from.buckets.inner[k].values.data
+		b := &multiMapBucket[V]{values: NewArray(make([]V, len(values)))}
+		if m.unique {
+			b.index = make(map[V]struct{}, len(values))
+		}
+		for i, v := range values {
+			b.values.data[i] = cloneValue(s, v)
+			if m.unique {
+				b.index[b.values.data[i]] = struct{}{}
+			}
+		}
+		m.buckets.insert(k, b)
+	}
+}
+
+func (m *MultiMap[K, V]) Next() (*MapEntry[K, V], bool) {
+	for m.iterKeyIdx < len(m.buckets.keys) {
+		key := m.buckets.keys[m.iterKeyIdx]
+		values := m.buckets.inner[key].values.data
+		if m.iterValueIdx < len(values) {
+			retVal := &MapEntry[K, V]{key, values[m.iterValueIdx]}
+			m.iterValueIdx++
+			return retVal, false
+		}
+		m.iterKeyIdx++
+		m.iterValueIdx = 0
+	}
+	return nil, true
+}
+
+func (m *MultiMap[K, V]) IterReset() {
+	m.iterKeyIdx = 0
+	m.iterValueIdx = 0
+}
+
+// Returns an iterator over an entry for every value, in the order of
+// the keys
+func (m *MultiMap[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return Iterator(m)
+}
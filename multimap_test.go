@@ -0,0 +1,86 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiMapAddAndGet(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMultiMap[string, int]()
+	m.Add("b", 1).AddAll("a", 2, 3).Add("b", 1)
+
+	assert.Equal([]int{1, 1}, m.Get("b").ToSlice())
+	assert.Equal([]int{2, 3}, m.Get("a").ToSlice())
+	assert.Equal(0, m.Get("missing").Length())
+	assert.Equal(2, m.Count())
+	assert.Equal(4, m.CountValues())
+	assert.True(m.HasValue("a", 3))
+	assert.False(m.HasValue("a", 1))
+
+	values := m.Get("a")
+	values.Push(100)
+	assert.Equal([]int{2, 3}, m.Get("a").ToSlice())
+}
+
+func TestMultiMapRemove(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMultiMap[string, int]()
+	m.AddAll("a", 1, 2, 1).Add("b", 3)
+
+	assert.True(m.Remove("a", 1))
+	assert.Equal([]int{2, 1}, m.Get("a").ToSlice())
+	assert.False(m.Remove("a", 5))
+	assert.False(m.Remove("missing", 1))
+
+	assert.True(m.Remove("b", 3))
+	assert.False(m.Has("b"))
+	assert.Equal([]string{"a"}, m.Keys().ToSlice())
+
+	assert.Equal([]int{2, 1}, m.RemoveAll("a").ToSlice())
+	assert.Equal(0, m.Count())
+	assert.Equal(0, m.CountValues())
+	assert.Equal(0, m.RemoveAll("a").Length())
+}
+
+func TestSetMultiMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSetMultiMap[string, string]()
+	m.AddAll("tags", "go", "db", "go").Add("tags", "db")
+
+	assert.Equal([]string{"go", "db"}, m.Get("tags").ToSlice())
+	assert.Equal(2, m.CountValues())
+
+	m.Remove("tags", "go")
+	assert.False(m.HasValue("tags", "go"))
+	m.Add("tags", "go")
+	assert.Equal([]string{"db", "go"}, m.Get("tags").ToSlice())
+
+	copied := m.Copy()
+	copied.Add("tags", "db")
+	assert.Equal(2, copied.CountValues())
+}
+
+func TestMultiMapIteration(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMultiMap[string, int]()
+	m.Add("b", 1).Add("a", 2).Add("b", 3)
+
+	entries := []MapEntry[string, int]{}
+	for e := range m.Iter() {
+		entries = append(entries, *e)
+	}
+	assert.Equal(
+		[]MapEntry[string, int]{{"b", 1}, {"b", 3}, {"a", 2}},
+		entries,
+	)
+	assert.Equal([]int{1, 3, 2}, m.Values().ToSlice())
+	assert.Equal(3, m.Entries().Length())
+	assert.Equal([]int{1, 3}, m.ToMap()["b"].ToSlice())
+}
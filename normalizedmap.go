@@ -0,0 +1,191 @@
+package ezs
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Key normalizer making string keys case-insensitive. Two keys are
+// normalized to the same string whenever strings.EqualFold considers
+// them equal, as every rune is replaced with the same representative
+// of the runes it folds to. The result is not meant to be displayed.
+func CaseInsensitive(key string) string {
+	return strings.Map(foldRune, key)
+}
+
+// Returns the smallest rune of the simple case folding orbit of the
+// rune
+func foldRune(r rune) rune {
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		folded = min(folded, f)
+	}
+	return folded
+}
+
+// Map whose keys are passed through a normalizer function before
+// being looked up, so that different spellings of a key refer to the
+// same entry. The original spelling the key was first set with is
+// kept and returned by Keys and the other methods listing the keys.
+// Entries are kept in the order of insertion.
+type NormalizedMap[K comparable, V any] struct {
+	normalize func(K) K
+	entries   *Map[K, *MapEntry[K, V]]
+	iterIdx   int
+}
+
+func NewMapWithKeyFunc[K comparable, V any](normalize func(K) K) *NormalizedMap[K, V] {
+	return &NormalizedMap[K, V]{
+		normalize: normalize,
+		entries:   NewMap(map[K]*MapEntry[K, V]{}),
+	}
+}
+
+// Creates a map with case-insensitive string keys
+func NewCaseInsensitiveMap[V any]() *NormalizedMap[string, V] {
+	return NewMapWithKeyFunc[string, V](CaseInsensitive)
+}
+
+func (m *NormalizedMap[K, V]) entry(key K) (*MapEntry[K, V], bool) {
+	return m.entries.Get(m.normalize(key))
+}
+
+func (m *NormalizedMap[K, V]) Has(key K) bool {
+	_, ok := m.entry(key)
+	return ok
+}
+
+func (m *NormalizedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.entry(key); ok {
+		return e.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Returns the original spelling of the key stored in the map
+func (m *NormalizedMap[K, V]) Key(key K) (K, bool) {
+	if e, ok := m.entry(key); ok {
+		return e.Key, true
+	}
+	var zero K
+	return zero, false
+}
+
+// Sets the value of the key. If the map already has an entry with the
+// same normalized key, its value is replaced while its original key
+// is kept.
+func (m *NormalizedMap[K, V]) Set(key K, value V) *NormalizedMap[K, V] {
+	if e, ok := m.entry(key); ok {
+		e.Value = value
+		return m
+	}
+	m.entries.Set(m.normalize(key), &MapEntry[K, V]{key, value})
+	return m
+}
+
+func (m *NormalizedMap[K, V]) Delete(key K) *NormalizedMap[K, V] {
+	m.entries.Delete(m.normalize(key))
+	return m
+}
+
+func (m *NormalizedMap[K, V]) Count() int {
+	return m.entries.Count()
+}
+
+func (m *NormalizedMap[K, V]) ordered() []*MapEntry[K, V] {
+	entries := make([]*MapEntry[K, V], len(m.entries.keys))
+	for i, k := range m.entries.keys {
+		entries[i] = m.entries.inner[k]
+	}
+	return entries
+}
+
+func (m *NormalizedMap[K, V]) Keys() *Array[K] {
+	return MapTo(NewArray(m.ordered()), func(e *MapEntry[K, V]) K {
+		return e.Key
+	})
+}
+
+func (m *NormalizedMap[K, V]) Values() *Array[V] {
+	return MapTo(NewArray(m.ordered()), func(e *MapEntry[K, V]) V {
+		return e.Value
+	})
+}
+
+func (m *NormalizedMap[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	return MapTo(NewArray(m.ordered()), func(e *MapEntry[K, V]) *MapEntry[K, V] {
+		return &MapEntry[K, V]{e.Key, e.Value}
+	})
+}
+
+func (m *NormalizedMap[K, V]) ForEach(fn func(key K, value V)) {
+	for _, e := range m.ordered() {
+		fn(e.Key, e.Value)
+	}
+}
+
+// Returns a map with the original keys
+func (m *NormalizedMap[K, V]) ToMap() map[K]V {
+	newMap := make(map[K]V, m.Count())
+	for _, e := range m.ordered() {
+		newMap[e.Key] = e.Value
+	}
+	return newMap
+}
+
+func (m *NormalizedMap[K, V]) Find(fn func(key K, value V) bool) (V, bool) {
+	for _, e := range m.ordered() {
+		if fn(e.Key, e.Value) {
+			return e.Value, true
+		}
+	}
+	var zeroV V
+	return zeroV, false
+}
+
+func (m *NormalizedMap[K, V]) FindKey(fn func(key K, value V) bool) (K, bool) {
+	for _, e := range m.ordered() {
+		if fn(e.Key, e.Value) {
+			return e.Key, true
+		}
+	}
+	var zeroK K
+	return zeroK, false
+}
+
+// Creates a shallow copy of the map using the same normalizer
+func (m *NormalizedMap[K, V]) Copy() *NormalizedMap[K, V] {
+	c := NewMapWithKeyFunc[K, V](m.normalize)
+	for _, e := range m.ordered() {
+		c.Set(e.Key, e.Value)
+	}
+	return c
+}
+
+func (m *NormalizedMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*NormalizedMap[K, V])
+	m.normalize = from.normalize
+	m.entries = NewMap(map[K]*MapEntry[K, V]{})
+	for _, k := range from.entries.keys {
+		e := from.entries.inner[k]
+		m.entries.insert(k, &MapEntry[K, V]{e.Key, cloneValue(s, e.Value)})
+	}
+}
+
+func (m *NormalizedMap[K, V]) Next() (*MapEntry[K, V], bool) {
+	if m.iterIdx < len(m.entries.keys) {
+		e := m.entries.inner[m.entries.keys[m.iterIdx]]
+		m.iterIdx++
+		return &MapEntry[K, V]{e.Key, e.Value}, false
+	}
+	return nil, true
+}
+
+func (m *NormalizedMap[K, V]) IterReset() {
+	m.iterIdx = 0
+}
+
+func (m *NormalizedMap[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return Iterator(m)
+}
@@ -0,0 +1,88 @@
+package ezs_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseInsensitiveMap(t *testing.T) {
+	assert := assert.New(t)
+
+	headers := NewCaseInsensitiveMap[string]()
+	headers.Set("Content-Type", "text/plain")
+	headers.Set("X-Request-Id", "1")
+	headers.Set("content-type", "application/json")
+
+	assert.Equal(2, headers.Count())
+	assert.True(headers.Has("CONTENT-TYPE"))
+
+	v, ok := headers.Get("content-TYPE")
+	assert.True(ok)
+	assert.Equal("application/json", v)
+
+	key, ok := headers.Key("x-request-id")
+	assert.True(ok)
+	assert.Equal("X-Request-Id", key)
+
+	assert.Equal([]string{"Content-Type", "X-Request-Id"}, headers.Keys().ToSlice())
+	assert.Equal(
+		map[string]string{"Content-Type": "application/json", "X-Request-Id": "1"},
+		headers.ToMap(),
+	)
+
+	headers.Delete("X-REQUEST-ID")
+	assert.False(headers.Has("X-Request-Id"))
+
+	headers.Set("x-request-id", "2")
+	assert.Equal([]string{"Content-Type", "x-request-id"}, headers.Keys().ToSlice())
+}
+
+func TestMapWithKeyFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	handles := NewMapWithKeyFunc[string, int](func(k string) string {
+		return strings.TrimPrefix(strings.ToLower(k), "@")
+	})
+	handles.Set("@Alice", 1).Set("bob", 2)
+
+	assert.True(handles.Has("alice"))
+	assert.True(handles.Has("@BOB"))
+
+	keys := []string{}
+	for e := range handles.Iter() {
+		keys = append(keys, e.Key)
+	}
+	assert.Equal([]string{"@Alice", "bob"}, keys)
+
+	copied := handles.Copy()
+	copied.Delete("ALICE")
+	assert.True(handles.Has("alice"))
+	assert.False(copied.Has("@alice"))
+	assert.Equal([]int{2}, copied.Values().ToSlice())
+}
+
+func TestCaseInsensitiveFolding(t *testing.T) {
+	assert := assert.New(t)
+
+	words := []string{"σ", "ς", "Σ", "k", "K", "\u212a", "s", "ſ", "ß", "SS", "Straße", "STRASSE", "İ", "i", "ı"}
+	for _, a := range words {
+		for _, b := range words {
+			assert.Equal(
+				strings.EqualFold(a, b),
+				CaseInsensitive(a) == CaseInsensitive(b),
+				a+" "+b,
+			)
+		}
+	}
+
+	m := NewCaseInsensitiveMap[int]()
+	m.Set("ΟΔΟΣ", 1)
+	_, ok := m.Get("οδος")
+	assert.True(ok)
+	_, ok = m.Get("οδοσ")
+	assert.True(ok)
+	assert.Equal([]string{"ΟΔΟΣ"}, m.Keys().ToSlice())
+}
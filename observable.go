@@ -0,0 +1,131 @@
+package ezs
+
+import "sync"
+
+// Kind of a change emitted by an observable collection
+type ChangeKind int
+
+const (
+	ChangeInsert ChangeKind = iota
+	ChangeRemove
+	ChangeUpdate
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeInsert:
+		return "insert"
+	case ChangeRemove:
+		return "remove"
+	case ChangeUpdate:
+		return "update"
+	}
+	return "unknown"
+}
+
+type subscriber[C any] struct {
+	fn func([]C)
+}
+
+// Subscriber list shared by the observable collections. Changes are
+// delivered to the subscribers right away, or collected and delivered
+// together once the outermost batch finishes.
+type observers[C any] struct {
+	mu         sync.Mutex
+	subs       []*subscriber[C]
+	batchDepth int
+	pending    []C
+}
+
+func (o *observers[C]) subscribe(fn func([]C)) func() {
+	sub := &subscriber[C]{fn: fn}
+	o.mu.Lock()
+	o.subs = append(o.subs, sub)
+	o.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			o.mu.Lock()
+			defer o.mu.Unlock()
+			for i, s := range o.subs {
+				if s == sub {
+					o.subs = append(o.subs[:i:i], o.subs[i+1:]...)
+					break
+				}
+			}
+		})
+	}
+}
+
+// Subscribes a channel which receives the changes. A send blocks the
+// mutating call until the channel is read from or the subscription is
+// cancelled. The channel is closed when the subscription is cancelled.
+func (o *observers[C]) subscribeChan(buffer int) (<-chan []C, func()) {
+	ch := make(chan []C, buffer)
+	done := make(chan struct{})
+	var sending sync.Mutex
+
+	unsubscribe := o.subscribe(func(changes []C) {
+		sending.Lock()
+		defer sending.Unlock()
+		select {
+		case <-done:
+		default:
+			select {
+			case ch <- changes:
+			case <-done:
+			}
+		}
+	})
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			unsubscribe()
+			close(done)
+			sending.Lock()
+			close(ch)
+			sending.Unlock()
+		})
+	}
+}
+
+func (o *observers[C]) emit(changes ...C) {
+	if len(changes) == 0 {
+		return
+	}
+	o.mu.Lock()
+	if o.batchDepth > 0 {
+		o.pending = append(o.pending, changes...)
+		o.mu.Unlock()
+		return
+	}
+	subs := append([]*subscriber[C](nil), o.subs...)
+	o.mu.Unlock()
+
+	for _, s := range subs {
+		s.fn(changes)
+	}
+}
+
+func (o *observers[C]) batch(fn func()) {
+	o.mu.Lock()
+	o.batchDepth++
+	o.mu.Unlock()
+
+	defer func() {
+		o.mu.Lock()
+		o.batchDepth--
+		if o.batchDepth > 0 {
+			o.mu.Unlock()
+			return
+		}
+		pending := o.pending
+		o.pending = nil
+		o.mu.Unlock()
+		o.emit(pending...)
+	}()
+
+	fn()
+}
@@ -0,0 +1,227 @@
+package ezs
+
+// Change made to an ObservableArray. The index of a change refers to
+// the array as it was after the preceding changes of the same
+// notification were applied, so replaying the changes in order on a
+// copy of the old array produces the new one. OldValue is set for
+// removals and updates, NewValue for insertions and updates.
+type ArrayChange[T any] struct {
+	Kind     ChangeKind
+	Index    int
+	OldValue T
+	NewValue T
+}
+
+// Array which notifies its subscribers of every modification. Each
+// modifying call results in one notification holding all the changes
+// it made, unless it is made within Batch.
+type ObservableArray[T any] struct {
+	array     *Array[T]
+	observers observers[ArrayChange[T]]
+}
+
+func NewObservableArray[T any](data []T) *ObservableArray[T] {
+	return &ObservableArray[T]{array: NewArray(data)}
+}
+
+// Registers a function called with the changes after every
+// modification, and returns a function cancelling the subscription
+func (a *ObservableArray[T]) Subscribe(fn func(changes []ArrayChange[T])) func() {
+	return a.observers.subscribe(fn)
+}
+
+// Returns a channel receiving the changes after every modification,
+// and a function cancelling the subscription and closing the channel.
+// Modifications block while the channel buffer is full.
+func (a *ObservableArray[T]) SubscribeChan(buffer int) (<-chan []ArrayChange[T], func()) {
+	return a.observers.subscribeChan(buffer)
+}
+
+// Runs the function and delivers all the changes it made in a single
+// notification once it returns
+func (a *ObservableArray[T]) Batch(fn func()) {
+	a.observers.batch(fn)
+}
+
+func (a *ObservableArray[T]) inserted(start int, data []T) []ArrayChange[T] {
+	changes := make([]ArrayChange[T], len(data))
+	for i, v := range data {
+		changes[i] = ArrayChange[T]{Kind: ChangeInsert, Index: start + i, NewValue: v}
+	}
+	return changes
+}
+
+func (a *ObservableArray[T]) removed(start int, data []T) []ArrayChange[T] {
+	changes := make([]ArrayChange[T], len(data))
+	for i, v := range data {
+		changes[i] = ArrayChange[T]{Kind: ChangeRemove, Index: start, OldValue: v}
+	}
+	return changes
+}
+
+// Emits an update for every index, used by the methods reordering
+// the elements
+func (a *ObservableArray[T]) reordered(old []T) []ArrayChange[T] {
+	changes := make([]ArrayChange[T], len(old))
+	for i, v := range old {
+		changes[i] = ArrayChange[T]{Kind: ChangeUpdate, Index: i, OldValue: v, NewValue: a.array.data[i]}
+	}
+	return changes
+}
+
+// Adds new elements to the end of the array
+func (a *ObservableArray[T]) Push(data ...T) *ObservableArray[T] {
+	start := a.array.Length()
+	a.array.Push(data...)
+	a.observers.emit(a.inserted(start, data)...)
+	return a
+}
+
+// Adds new elements to the beginning of the array
+func (a *ObservableArray[T]) Unshift(data ...T) *ObservableArray[T] {
+	a.array.Unshift(data...)
+	a.observers.emit(a.inserted(0, data)...)
+	return a
+}
+
+// Removes the last element from an array and returns that element
+func (a *ObservableArray[T]) Pop() T {
+	v := a.array.Pop()
+	a.observers.emit(ArrayChange[T]{Kind: ChangeRemove, Index: a.array.Length(), OldValue: v})
+	return v
+}
+
+// Removes the first element from an array and returns that element
+func (a *ObservableArray[T]) Shift() T {
+	v := a.array.Shift()
+	a.observers.emit(ArrayChange[T]{Kind: ChangeRemove, Index: 0, OldValue: v})
+	return v
+}
+
+// Returns the element at the specified index
+func (a *ObservableArray[T]) At(idx int) T {
+	return a.array.At(idx)
+}
+
+// Changes the value at the specified index
+func (a *ObservableArray[T]) Set(idx int, data T) *ObservableArray[T] {
+	old := a.array.data[idx]
+	a.array.Set(idx, data)
+	a.observers.emit(ArrayChange[T]{Kind: ChangeUpdate, Index: idx, OldValue: old, NewValue: data})
+	return a
+}
+
+// Inserts new elements at the specified index, shifting the
+// elements after the index
+func (a *ObservableArray[T]) Insert(at int, data ...T) *ObservableArray[T] {
+	a.array.Insert(at, data...)
+	a.observers.emit(a.inserted(at, data)...)
+	return a
+}
+
+// Returns the length of the array
+func (a *ObservableArray[T]) Length() int {
+	return a.array.Length()
+}
+
+// Removes {count} elements from an array starting from the given index
+// and returns the removed elements
+func (a *ObservableArray[T]) Splice(start, count int) *Array[T] {
+	removed := a.array.Splice(start, count)
+	a.observers.emit(a.removed(start, removed.data)...)
+	return removed
+}
+
+// Removes {count} elements from an array starting from the given index,
+// inserts new elements in their place and returns the removed elements
+func (a *ObservableArray[T]) Replace(start, count int, data ...T) *Array[T] {
+	removed := a.array.Replace(start, count, data...)
+	a.observers.emit(append(a.removed(start, removed.data), a.inserted(start, data)...)...)
+	return removed
+}
+
+// Concatenates in place the elements of a provided array
+func (a *ObservableArray[T]) Concat(arr *Array[T]) *ObservableArray[T] {
+	start := a.array.Length()
+	a.array.Concat(arr)
+	a.observers.emit(a.inserted(start, a.array.data[start:])...)
+	return a
+}
+
+// Reverses the array in place
+func (a *ObservableArray[T]) Reverse() *ObservableArray[T] {
+	old := a.array.ToSlice()
+	a.array.Reverse()
+	a.observers.emit(a.reordered(old)...)
+	return a
+}
+
+// Removes elements from the array that satisfy the predicate
+func (a *ObservableArray[T]) Remove(predicate func(T, int) bool) *ObservableArray[T] {
+	var changes []ArrayChange[T]
+	kept := make([]T, 0, len(a.array.data))
+	for idx, v := range a.array.data {
+		if predicate(v, idx) {
+			changes = append(changes, ArrayChange[T]{
+				Kind:     ChangeRemove,
+				Index:    idx - len(changes),
+				OldValue: v,
+			})
+		} else {
+			kept = append(kept, v)
+		}
+	}
+	a.array.replaceData(kept)
+	a.observers.emit(changes...)
+	return a
+}
+
+// Sorts the array in place
+func (a *ObservableArray[T]) SortWith(compare func(T, T) int) {
+	old := a.array.ToSlice()
+	a.array.SortWith(compare)
+	a.observers.emit(a.reordered(old)...)
+}
+
+// Sorts the array in place in reverse order
+func (a *ObservableArray[T]) SortWithReverse(compare func(T, T) int) {
+	old := a.array.ToSlice()
+	a.array.SortWithReverse(compare)
+	a.observers.emit(a.reordered(old)...)
+}
+
+// Returns a read-only view of the array
+func (a *ObservableArray[T]) ReadOnly() *ReadOnlyArray[T] {
+	return a.array.ReadOnly()
+}
+
+// Creates a new slice with the same elements as the array and returns it
+func (a *ObservableArray[T]) ToSlice() []T {
+	return a.array.ToSlice()
+}
+
+// Returns an iterator over the elements of the array
+func (a *ObservableArray[T]) Iter() func(func(T) bool) {
+	return a.ReadOnly().Iter()
+}
+
+// The subscribers are not copied
+func (a *ObservableArray[T]) deepCopyFrom(src any, s *cloneState) {
+	a.array = cloneValue(s, src.(*ObservableArray[T]).array)
+}
+
+// Replays the changes in a single notification
+func (a *ObservableArray[T]) apply(changes []ArrayChange[T]) {
+	a.Batch(func() {
+		for _, c := range changes {
+			switch c.Kind {
+			case ChangeInsert:
+				a.Insert(c.Index, c.NewValue)
+			case ChangeRemove:
+				a.Splice(c.Index, 1)
+			case ChangeUpdate:
+				a.Set(c.Index, c.NewValue)
+			}
+		}
+	})
+}
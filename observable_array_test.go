@@ -0,0 +1,126 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+// Applies the changes to a copy of the given slice
+func replayArrayChanges[T any](data []T, changes []ArrayChange[T]) []T {
+	arr := NewArray(append([]T{}, data...))
+	for _, c := range changes {
+		switch c.Kind {
+		case ChangeInsert:
+			arr.Insert(c.Index, c.NewValue)
+		case ChangeRemove:
+			arr.Splice(c.Index, 1)
+		case ChangeUpdate:
+			arr.Set(c.Index, c.NewValue)
+		}
+	}
+	return arr.ToSlice()
+}
+
+func TestObservableArrayChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewObservableArray([]int{1, 2, 3})
+	var received [][]ArrayChange[int]
+	arr.Subscribe(func(changes []ArrayChange[int]) {
+		received = append(received, changes)
+	})
+
+	arr.Push(4, 5)
+	arr.Set(0, 10)
+	arr.Pop()
+
+	assert.Equal([][]ArrayChange[int]{
+		{
+			{Kind: ChangeInsert, Index: 3, NewValue: 4},
+			{Kind: ChangeInsert, Index: 4, NewValue: 5},
+		},
+		{{Kind: ChangeUpdate, Index: 0, OldValue: 1, NewValue: 10}},
+		{{Kind: ChangeRemove, Index: 4, OldValue: 5}},
+	}, received)
+	assert.Equal([]int{10, 2, 3, 4}, arr.ToSlice())
+}
+
+func TestObservableArrayReplay(t *testing.T) {
+	assert := assert.New(t)
+
+	mutations := map[string]func(*ObservableArray[int]){
+		"Unshift":  func(a *ObservableArray[int]) { a.Unshift(-1, 0) },
+		"Shift":    func(a *ObservableArray[int]) { a.Shift() },
+		"Insert":   func(a *ObservableArray[int]) { a.Insert(2, 7, 8) },
+		"Splice":   func(a *ObservableArray[int]) { a.Splice(1, 3) },
+		"Replace":  func(a *ObservableArray[int]) { a.Replace(1, 2, 9, 9, 9) },
+		"Concat":   func(a *ObservableArray[int]) { a.Concat(NewArray([]int{6, 7})) },
+		"Reverse":  func(a *ObservableArray[int]) { a.Reverse() },
+		"SortWith": func(a *ObservableArray[int]) { a.SortWith(func(a, b int) int { return a - b }) },
+		"Remove": func(a *ObservableArray[int]) {
+			a.Remove(func(v, _ int) bool { return v%2 == 1 })
+		},
+		"SortWithReverse": func(a *ObservableArray[int]) {
+			a.SortWithReverse(func(a, b int) int { return a - b })
+		},
+	}
+
+	for name, mutate := range mutations {
+		initial := []int{3, 1, 4, 1, 5}
+		arr := NewObservableArray(append([]int{}, initial...))
+		var changes []ArrayChange[int]
+		arr.Subscribe(func(c []ArrayChange[int]) {
+			changes = append(changes, c...)
+		})
+
+		mutate(arr)
+		assert.Equal(arr.ToSlice(), replayArrayChanges(initial, changes), name)
+	}
+}
+
+func TestObservableArrayBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewObservableArray([]string{})
+	notifications := 0
+	var changes []ArrayChange[string]
+	arr.Subscribe(func(c []ArrayChange[string]) {
+		notifications++
+		changes = c
+	})
+
+	arr.Batch(func() {
+		arr.Push("a")
+		arr.Batch(func() {
+			arr.Push("b")
+		})
+		assert.Equal(0, notifications)
+		arr.Shift()
+	})
+
+	assert.Equal(1, notifications)
+	assert.Len(changes, 3)
+	assert.Equal([]string{"b"}, replayArrayChanges([]string{}, changes))
+
+	arr.Batch(func() {})
+	assert.Equal(1, notifications)
+}
+
+func TestObservableArrayUnsubscribe(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewObservableArray([]int{})
+	calls := 0
+	unsubscribe := arr.Subscribe(func([]ArrayChange[int]) {
+		calls++
+	})
+
+	arr.Push(1)
+	unsubscribe()
+	unsubscribe()
+	arr.Push(2)
+
+	assert.Equal(1, calls)
+}
@@ -0,0 +1,128 @@
+package ezs
+
+import "slices"
+
+// Change made to an ObservableMap. OldValue is set for removals and
+// updates, NewValue for insertions and updates. Index is the position
+// of the key in the key order of the map, for insertions and removals.
+type MapChange[K comparable, V any] struct {
+	Kind     ChangeKind
+	Key      K
+	Index    int
+	OldValue V
+	NewValue V
+}
+
+// Map which notifies its subscribers of every modification. Each
+// modifying call results in one notification holding all the changes
+// it made, unless it is made within Batch.
+type ObservableMap[K comparable, V any] struct {
+	inner     *Map[K, V]
+	observers observers[MapChange[K, V]]
+}
+
+// Creates an observable map of the entries of the given map, which may
+// be nil
+func NewObservableMap[K comparable, V any](inner map[K]V) *ObservableMap[K, V] {
+	if inner == nil {
+		inner = map[K]V{}
+	}
+	return &ObservableMap[K, V]{inner: NewMap(inner)}
+}
+
+// Registers a function called with the changes after every
+// modification, and returns a function cancelling the subscription
+func (m *ObservableMap[K, V]) Subscribe(fn func(changes []MapChange[K, V])) func() {
+	return m.observers.subscribe(fn)
+}
+
+// Returns a channel receiving the changes after every modification,
+// and a function cancelling the subscription and closing the channel.
+// Modifications block while the channel buffer is full.
+func (m *ObservableMap[K, V]) SubscribeChan(buffer int) (<-chan []MapChange[K, V], func()) {
+	return m.observers.subscribeChan(buffer)
+}
+
+// Runs the function and delivers all the changes it made in a single
+// notification once it returns
+func (m *ObservableMap[K, V]) Batch(fn func()) {
+	m.observers.batch(fn)
+}
+
+func (m *ObservableMap[K, V]) Has(key K) bool {
+	return m.inner.Has(key)
+}
+
+func (m *ObservableMap[K, V]) Get(key K) (V, bool) {
+	return m.inner.Get(key)
+}
+
+func (m *ObservableMap[K, V]) Set(key K, value V) *ObservableMap[K, V] {
+	old, ok := m.inner.Get(key)
+	m.inner.Set(key, value)
+	if ok {
+		m.observers.emit(MapChange[K, V]{Kind: ChangeUpdate, Key: key, OldValue: old, NewValue: value})
+	} else {
+		m.observers.emit(MapChange[K, V]{Kind: ChangeInsert, Key: key, Index: m.inner.Count() - 1, NewValue: value})
+	}
+	return m
+}
+
+// Removes the entry of the given key. Nothing is emitted if there is
+// no such entry.
+func (m *ObservableMap[K, V]) Delete(key K) *ObservableMap[K, V] {
+	old, ok := m.inner.Get(key)
+	if !ok {
+		return m
+	}
+	idx := slices.Index(m.inner.keys, key)
+	m.inner.Delete(key)
+	m.observers.emit(MapChange[K, V]{Kind: ChangeRemove, Key: key, Index: idx, OldValue: old})
+	return m
+}
+
+func (m *ObservableMap[K, V]) Count() int {
+	return m.inner.Count()
+}
+
+func (m *ObservableMap[K, V]) Keys() *Array[K] {
+	return m.inner.Keys()
+}
+
+func (m *ObservableMap[K, V]) ToMap() map[K]V {
+	return m.inner.ToMap()
+}
+
+// Returns a read-only view of the map
+func (m *ObservableMap[K, V]) ReadOnly() *ReadOnlyMap[K, V] {
+	return m.inner.ReadOnly()
+}
+
+// Returns an iterator over the entries of the map
+func (m *ObservableMap[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return m.ReadOnly().Iter()
+}
+
+// The subscribers are not copied
+func (m *ObservableMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	m.inner = cloneValue(s, src.(*ObservableMap[K, V]).inner)
+}
+
+// Replays the changes in a single notification. Inserted keys are put
+// back at the position of the change.
+func (m *ObservableMap[K, V]) apply(changes []MapChange[K, V]) {
+	m.Batch(func() {
+		for _, c := range changes {
+			switch {
+			case c.Kind == ChangeRemove:
+				m.Delete(c.Key)
+			case c.Kind == ChangeInsert && !m.inner.Has(c.Key):
+				m.inner.insertAt(c.Index, c.Key, c.NewValue)
+				c.Index = min(c.Index, m.inner.Count()-1)
+				m.observers.emit(c)
+			default:
+				m.Set(c.Key, c.NewValue)
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableMapChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewObservableMap(map[string]int{"one": 1})
+	var received []MapChange[string, int]
+	m.Subscribe(func(changes []MapChange[string, int]) {
+		received = append(received, changes...)
+	})
+
+	m.Set("two", 2)
+	m.Set("one", 10)
+	m.Delete("two")
+	m.Delete("missing")
+
+	assert.Equal([]MapChange[string, int]{
+		{Kind: ChangeInsert, Key: "two", Index: 1, NewValue: 2},
+		{Kind: ChangeUpdate, Key: "one", OldValue: 1, NewValue: 10},
+		{Kind: ChangeRemove, Key: "two", Index: 1, OldValue: 2},
+	}, received)
+	assert.Equal(map[string]int{"one": 10}, m.ToMap())
+}
+
+func TestObservableMapBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewObservableMap(map[string]int{})
+	var notifications [][]MapChange[string, int]
+	m.Subscribe(func(changes []MapChange[string, int]) {
+		notifications = append(notifications, changes)
+	})
+
+	m.Batch(func() {
+		m.Set("a", 1).Set("b", 2).Delete("a")
+	})
+
+	assert.Len(notifications, 1)
+	assert.Equal([]ChangeKind{ChangeInsert, ChangeInsert, ChangeRemove}, []ChangeKind{
+		notifications[0][0].Kind,
+		notifications[0][1].Kind,
+		notifications[0][2].Kind,
+	})
+}
+
+func TestObservableMapBatchPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewObservableMap(map[string]int{})
+	notifications := 0
+	m.Subscribe(func([]MapChange[string, int]) {
+		notifications++
+	})
+
+	assert.Panics(func() {
+		m.Batch(func() {
+			m.Set("a", 1)
+			panic("failed")
+		})
+	})
+	assert.Equal(1, notifications)
+
+	m.Set("b", 2)
+	assert.Equal(2, notifications)
+}
+
+func TestObservableMapFromNil(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewObservableMap[string, int](nil)
+	m.Set("a", 1)
+	assert.Equal(map[string]int{"a": 1}, m.ToMap())
+}
@@ -0,0 +1,57 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeKindString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("insert", ChangeInsert.String())
+	assert.Equal("remove", ChangeRemove.String())
+	assert.Equal("update", ChangeUpdate.String())
+}
+
+func TestSubscribeChan(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewObservableMap(map[string]int{})
+	ch, unsubscribe := m.SubscribeChan(1)
+
+	m.Set("a", 1)
+	changes := <-ch
+	assert.Equal([]MapChange[string, int]{{Kind: ChangeInsert, Key: "a", NewValue: 1}}, changes)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range ch {
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		m.Set("b", i)
+	}
+	unsubscribe()
+	<-done
+
+	m.Set("c", 3)
+	_, open := <-ch
+	assert.False(open)
+}
+
+func TestSubscribeChanUnsubscribeUnblocksSend(t *testing.T) {
+	arr := NewObservableArray([]int{})
+	_, unsubscribe := arr.SubscribeChan(0)
+
+	pushed := make(chan struct{})
+	go func() {
+		defer close(pushed)
+		arr.Push(1)
+	}()
+
+	unsubscribe()
+	<-pushed
+}
@@ -0,0 +1,328 @@
+package ezs
+
+import (
+	"slices"
+	"strings"
+)
+
+type prefixNode[V any] struct {
+	prefix   string
+	children []*prefixNode[V]
+	value    V
+	hasValue bool
+}
+
+// Returns the index of the child starting with the given byte and the
+// child itself, or the index the child should be inserted at and nil
+func (n *prefixNode[V]) child(b byte) (int, *prefixNode[V]) {
+	for i, c := range n.children {
+		if c.prefix[0] == b {
+			return i, c
+		}
+		if c.prefix[0] > b {
+			return i, nil
+		}
+	}
+	return len(n.children), nil
+}
+
+// Merges the node with its only child if the node holds no value
+func (n *prefixNode[V]) compact() {
+	if n.hasValue || len(n.children) != 1 {
+		return
+	}
+	c := n.children[0]
+	n.prefix += c.prefix
+	n.children = c.children
+	n.value = c.value
+	n.hasValue = c.hasValue
+}
+
+func (n *prefixNode[V]) walk(key string, fn func(string, V) bool) bool {
+	key += n.prefix
+	if n.hasValue && !fn(key, n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(key, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *prefixNode[V]) deepCopy(s *cloneState) *prefixNode[V] {
+	c := &prefixNode[V]{prefix: n.prefix, hasValue: n.hasValue}
+	if n.hasValue {
+		c.value = cloneValue(s, n.value)
+	}
+	c.children = make([]*prefixNode[V], len(n.children))
+	for i, child := range n.children {
+		c.children[i] = child.deepCopy(s)
+	}
+	return c
+}
+
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Map with string keys stored in a radix tree, which allows to look up
+// all the keys starting with a prefix. Entries are iterated over in
+// the lexicographic order of the keys.
+type PrefixMap[V any] struct {
+	root  *prefixNode[V]
+	count int
+}
+
+func NewPrefixMap[V any](inner map[string]V) *PrefixMap[V] {
+	m := &PrefixMap[V]{root: &prefixNode[V]{}}
+	for k, v := range inner {
+		m.Set(k, v)
+	}
+	return m
+}
+
+func (m *PrefixMap[V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*PrefixMap[V])
+	m.root = from.root.deepCopy(s)
+	m.count = from.count
+}
+
+// Creates a new PrefixMap containing all entries of the given Map
+func PrefixMapFrom[V any](m *Map[string, V]) *PrefixMap[V] {
+	return NewPrefixMap(m.inner)
+}
+
+func (m *PrefixMap[V]) find(key string) *prefixNode[V] {
+	n := m.root
+	for key != "" {
+		_, c := n.child(key[0])
+		if c == nil || !strings.HasPrefix(key, c.prefix) {
+			return nil
+		}
+		key = key[len(c.prefix):]
+		n = c
+	}
+	return n
+}
+
+func (m *PrefixMap[V]) Has(key string) bool {
+	n := m.find(key)
+	return n != nil && n.hasValue
+}
+
+func (m *PrefixMap[V]) Get(key string) (V, bool) {
+	n := m.find(key)
+	if n == nil || !n.hasValue {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+func (m *PrefixMap[V]) Set(key string, value V) *PrefixMap[V] {
+	n := m.root
+	for key != "" {
+		i, c := n.child(key[0])
+		if c == nil {
+			leaf := &prefixNode[V]{prefix: key}
+			n.children = slices.Insert(n.children, i, leaf)
+			n = leaf
+			break
+		}
+
+		common := commonPrefixLen(key, c.prefix)
+		if common < len(c.prefix) {
+			split := &prefixNode[V]{
+				prefix:   c.prefix[:common],
+				children: []*prefixNode[V]{c},
+			}
+			c.prefix = c.prefix[common:]
+			n.children[i] = split
+			c = split
+		}
+
+		key = key[common:]
+		n = c
+	}
+
+	if !n.hasValue {
+		m.count++
+	}
+	n.value = value
+	n.hasValue = true
+	return m
+}
+
+func (m *PrefixMap[V]) delete(n *prefixNode[V], key string) bool {
+	if key == "" {
+		if !n.hasValue {
+			return false
+		}
+		var zero V
+		n.value = zero
+		n.hasValue = false
+		return true
+	}
+
+	i, c := n.child(key[0])
+	if c == nil || !strings.HasPrefix(key, c.prefix) {
+		return false
+	}
+	if !m.delete(c, key[len(c.prefix):]) {
+		return false
+	}
+
+	if !c.hasValue && len(c.children) == 0 {
+		n.children = slices.Delete(n.children, i, i+1)
+	} else {
+		c.compact()
+	}
+	return true
+}
+
+func (m *PrefixMap[V]) Delete(key string) *PrefixMap[V] {
+	if m.delete(m.root, key) {
+		m.count--
+	}
+	return m
+}
+
+func (m *PrefixMap[V]) Count() int {
+	return m.count
+}
+
+// Returns the longest key in the map that is a prefix of the given
+// key, along with its value
+func (m *PrefixMap[V]) LongestPrefix(key string) (string, V, bool) {
+	var (
+		found    *prefixNode[V]
+		foundLen int
+		consumed int
+	)
+
+	n := m.root
+	for {
+		if n.hasValue {
+			found = n
+			foundLen = consumed
+		}
+		if consumed == len(key) {
+			break
+		}
+		_, c := n.child(key[consumed])
+		if c == nil || !strings.HasPrefix(key[consumed:], c.prefix) {
+			break
+		}
+		consumed += len(c.prefix)
+		n = c
+	}
+
+	if found == nil {
+		var zero V
+		return "", zero, false
+	}
+	return key[:foundLen], found.value, true
+}
+
+// Calls the function for each entry with a key starting with the
+// given prefix, in lexicographic order, until it returns false
+func (m *PrefixMap[V]) walkPrefix(prefix string, fn func(string, V) bool) {
+	n := m.root
+	key := ""
+	for prefix != "" {
+		_, c := n.child(prefix[0])
+		if c == nil {
+			return
+		}
+		if strings.HasPrefix(c.prefix, prefix) {
+			c.walk(key, fn)
+			return
+		}
+		if !strings.HasPrefix(prefix, c.prefix) {
+			return
+		}
+		key += c.prefix
+		prefix = prefix[len(c.prefix):]
+		n = c
+	}
+	n.walk(key[:len(key)-len(n.prefix)], fn)
+}
+
+// Returns a Map of all the entries with a key starting with the
+// given prefix, inserted in lexicographic order
+func (m *PrefixMap[V]) WithPrefix(prefix string) *Map[string, V] {
+	result := NewMap(map[string]V{})
+	m.walkPrefix(prefix, func(k string, v V) bool {
+		result.Set(k, v)
+		return true
+	})
+	return result
+}
+
+// Returns an iterator over the entries with a key starting with the
+// given prefix, in lexicographic order
+func (m *PrefixMap[V]) IterPrefix(prefix string) func(func(*MapEntry[string, V]) bool) {
+	return func(yield func(*MapEntry[string, V]) bool) {
+		m.walkPrefix(prefix, func(k string, v V) bool {
+			return yield(&MapEntry[string, V]{k, v})
+		})
+	}
+}
+
+// Calls the function for each entry in lexicographic order of the
+// keys, stops when the function returns false
+func (m *PrefixMap[V]) Walk(fn func(key string, value V) bool) {
+	m.root.walk("", fn)
+}
+
+func (m *PrefixMap[V]) ForEach(fn func(key string, value V)) {
+	m.root.walk("", func(k string, v V) bool {
+		fn(k, v)
+		return true
+	})
+}
+
+// Returns the keys in lexicographic order
+func (m *PrefixMap[V]) Keys() *Array[string] {
+	keys := make([]string, 0, m.count)
+	m.ForEach(func(k string, _ V) {
+		keys = append(keys, k)
+	})
+	return NewArray(keys)
+}
+
+// Returns the values in lexicographic order of their keys
+func (m *PrefixMap[V]) Values() *Array[V] {
+	values := make([]V, 0, m.count)
+	m.ForEach(func(_ string, v V) {
+		values = append(values, v)
+	})
+	return NewArray(values)
+}
+
+// Returns the entries in lexicographic order of the keys
+func (m *PrefixMap[V]) Entries() *Array[*MapEntry[string, V]] {
+	entries := make([]*MapEntry[string, V], 0, m.count)
+	m.ForEach(func(k string, v V) {
+		entries = append(entries, &MapEntry[string, V]{k, v})
+	})
+	return NewArray(entries)
+}
+
+func (m *PrefixMap[V]) ToMap() map[string]V {
+	newMap := make(map[string]V, m.count)
+	m.ForEach(func(k string, v V) {
+		newMap[k] = v
+	})
+	return newMap
+}
+
+func (m *PrefixMap[V]) Iter() func(func(*MapEntry[string, V]) bool) {
+	return m.IterPrefix("")
+}
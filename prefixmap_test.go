@@ -0,0 +1,171 @@
+package ezs_test
+
+import (
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixMapSetAndGet(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewPrefixMap(map[string]int{})
+	m.Set("/api/v1/users", 1).
+		Set("/api/v1", 2).
+		Set("/api/v2/users", 3).
+		Set("/api", 4).
+		Set("", 5)
+
+	assert.Equal(5, m.Count())
+
+	for key, expected := range map[string]int{
+		"/api/v1/users": 1,
+		"/api/v1":       2,
+		"/api/v2/users": 3,
+		"/api":          4,
+		"":              5,
+	} {
+		v, ok := m.Get(key)
+		assert.True(ok, key)
+		assert.Equal(expected, v, key)
+	}
+
+	_, ok := m.Get("/api/v")
+	assert.False(ok)
+	assert.False(m.Has("/api/v2"))
+	assert.False(m.Has("/api/v1/users/1"))
+
+	m.Set("/api", 40)
+	v, _ := m.Get("/api")
+	assert.Equal(40, v)
+	assert.Equal(5, m.Count())
+}
+
+func TestPrefixMapDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewPrefixMap(map[string]int{
+		"foo":    1,
+		"foobar": 2,
+		"foobaz": 3,
+		"fo":     4,
+	})
+
+	m.Delete("foo").Delete("missing").Delete("foob")
+
+	assert.Equal(3, m.Count())
+	assert.False(m.Has("foo"))
+	assert.True(m.Has("foobar"))
+	assert.True(m.Has("foobaz"))
+	assert.True(m.Has("fo"))
+
+	m.Delete("foobar").Delete("fo")
+	assert.Equal([]string{"foobaz"}, m.Keys().ToSlice())
+
+	m.Set("foo", 5)
+	assert.Equal([]string{"foo", "foobaz"}, m.Keys().ToSlice())
+}
+
+func TestPrefixMapLongestPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewPrefixMap(map[string]string{
+		"/":            "root",
+		"/api":         "api",
+		"/api/v1/user": "user",
+	})
+
+	prefix, v, ok := m.LongestPrefix("/api/v1/users/10")
+	assert.True(ok)
+	assert.Equal("/api/v1/user", prefix)
+	assert.Equal("user", v)
+
+	prefix, v, ok = m.LongestPrefix("/api/v2")
+	assert.True(ok)
+	assert.Equal("/api", prefix)
+	assert.Equal("api", v)
+
+	prefix, v, ok = m.LongestPrefix("/static")
+	assert.True(ok)
+	assert.Equal("/", prefix)
+	assert.Equal("root", v)
+
+	_, _, ok = m.LongestPrefix("static")
+	assert.False(ok)
+}
+
+func TestPrefixMapWithPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewPrefixMap(map[string]int{
+		"/api/v1/users":  1,
+		"/api/v1/groups": 2,
+		"/api/v1":        3,
+		"/api/v2/users":  4,
+		"/apis":          5,
+	})
+
+	assert.Equal(
+		[]string{"/api/v1", "/api/v1/groups", "/api/v1/users"},
+		m.WithPrefix("/api/v1").Keys().ToSlice(),
+	)
+	assert.Equal(
+		[]string{"/api/v1/groups", "/api/v1/users"},
+		m.WithPrefix("/api/v1/").Keys().ToSlice(),
+	)
+	assert.Equal(
+		[]string{"/api/v1", "/api/v1/groups", "/api/v1/users", "/api/v2/users", "/apis"},
+		m.WithPrefix("/ap").Keys().ToSlice(),
+	)
+	assert.Equal(0, m.WithPrefix("/api/v3").Count())
+
+	iteratedOver := []int{}
+	for e := range m.IterPrefix("/api/v1/") {
+		iteratedOver = append(iteratedOver, e.Value)
+	}
+	assert.Equal([]int{2, 1}, iteratedOver)
+}
+
+func TestPrefixMapWalk(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewPrefixMap(map[string]int{
+		"b":  1,
+		"a":  2,
+		"ab": 3,
+		"ba": 4,
+		"c":  5,
+	})
+
+	keys := []string{}
+	m.Walk(func(key string, value int) bool {
+		keys = append(keys, key)
+		return key != "b"
+	})
+
+	assert.Equal([]string{"a", "ab", "b"}, keys)
+	assert.Equal([]int{2, 3, 1, 4, 5}, m.Values().ToSlice())
+}
+
+func TestPrefixMapFrom(t *testing.T) {
+	assert := assert.New(t)
+
+	src := NewMap(map[string]int{
+		"one": 1,
+		"two": 2,
+	})
+	m := PrefixMapFrom(src)
+
+	assert.Equal(2, m.Count())
+	assert.Equal(map[string]int{"one": 1, "two": 2}, m.ToMap())
+
+	iteratedOver := []*MapEntry[string, int]{}
+	for e := range m.Iter() {
+		iteratedOver = append(iteratedOver, e)
+	}
+	assert.Equal(
+		[]*MapEntry[string, int]{{"one", 1}, {"two", 2}},
+		iteratedOver,
+	)
+}
@@ -0,0 +1,160 @@
+package ezs
+
+import "errors"
+
+// Value of the panic raised when a frozen Array or Map is modified
+var ErrFrozen = errors.New("ezs: collection is frozen")
+
+// Read-only view of an Array, exposes only the methods that do not
+// modify the array
+type ReadOnlyArray[T any] struct {
+	array *Array[T]
+}
+
+// Returns the element at the specified index
+func (r *ReadOnlyArray[T]) At(idx int) T {
+	return r.array.At(idx)
+}
+
+// Returns the length of the array
+func (r *ReadOnlyArray[T]) Length() int {
+	return r.array.Length()
+}
+
+// Returns a read-only view of a portion of the array. Unlike View it
+// does not modify the array, so it is safe to call concurrently.
+func (r *ReadOnlyArray[T]) Slice(start, end int) *ReadOnlyArray[T] {
+	return NewArray(r.array.data[start:end:end]).ReadOnly()
+}
+
+// Create a new array containing all the elements from the source array
+// that satisfy the given predicate
+func (r *ReadOnlyArray[T]) Filter(predicate func(T, int) bool) *Array[T] {
+	return r.array.Filter(predicate)
+}
+
+// Returns the first element in the array that satisfies the
+// predicate
+func (r *ReadOnlyArray[T]) Find(predicate func(T, int) bool) (bool, T) {
+	return r.array.Find(predicate)
+}
+
+// Returns the index of the first element in the array that
+// satisfies the predicate
+func (r *ReadOnlyArray[T]) FindIndex(predicate func(T, int) bool) int {
+	return r.array.FindIndex(predicate)
+}
+
+// Returns true if at least one element in the array satisfies
+// the predicate
+func (r *ReadOnlyArray[T]) Some(predicate func(T, int) bool) bool {
+	return r.array.Some(predicate)
+}
+
+// Returns true if all elements in the array satisfy the predicate
+func (r *ReadOnlyArray[T]) Every(predicate func(T, int) bool) bool {
+	return r.array.Every(predicate)
+}
+
+func (r *ReadOnlyArray[T]) ForEach(callback func(T, int)) {
+	r.array.ForEach(callback)
+}
+
+func (r *ReadOnlyArray[T]) deepCopyFrom(src any, s *cloneState) {
+	r.array = cloneValue(s, src.(*ReadOnlyArray[T]).array)
+}
+
+// Creates a mutable shallow copy of the array
+func (r *ReadOnlyArray[T]) Copy() *Array[T] {
+	return r.array.Copy()
+}
+
+// Creates a new slice with the same elements as the array and returns it
+func (r *ReadOnlyArray[T]) ToSlice() []T {
+	return r.array.ToSlice()
+}
+
+func (r *ReadOnlyArray[T]) Entries() []*ArrayEntry[T] {
+	return r.array.Entries()
+}
+
+// Returns an iterator over the elements of the array. The iterator
+// does not hold any state in the array, so the view can be iterated
+// over from multiple goroutines at once.
+func (r *ReadOnlyArray[T]) Iter() func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, v := range r.array.data {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Read-only view of a Map, exposes only the methods that do not
+// modify the map
+type ReadOnlyMap[K comparable, V any] struct {
+	inner *Map[K, V]
+}
+
+func (r *ReadOnlyMap[K, V]) Has(key K) bool {
+	return r.inner.Has(key)
+}
+
+func (r *ReadOnlyMap[K, V]) Get(key K) (V, bool) {
+	return r.inner.Get(key)
+}
+
+func (r *ReadOnlyMap[K, V]) Count() int {
+	return r.inner.Count()
+}
+
+func (r *ReadOnlyMap[K, V]) Keys() *Array[K] {
+	return r.inner.Keys()
+}
+
+func (r *ReadOnlyMap[K, V]) Values() *Array[V] {
+	return r.inner.Values()
+}
+
+func (r *ReadOnlyMap[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	return r.inner.Entries()
+}
+
+func (r *ReadOnlyMap[K, V]) ForEach(fn func(key K, value V)) {
+	r.inner.ForEach(fn)
+}
+
+func (r *ReadOnlyMap[K, V]) ToMap() map[K]V {
+	return r.inner.ToMap()
+}
+
+func (r *ReadOnlyMap[K, V]) Find(fn func(key K, value V) bool) (V, bool) {
+	return r.inner.Find(fn)
+}
+
+func (r *ReadOnlyMap[K, V]) FindKey(fn func(key K, value V) bool) (K, bool) {
+	return r.inner.FindKey(fn)
+}
+
+func (r *ReadOnlyMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	r.inner = cloneValue(s, src.(*ReadOnlyMap[K, V]).inner)
+}
+
+// Creates a mutable copy of the map
+func (r *ReadOnlyMap[K, V]) Copy() *Map[K, V] {
+	return r.inner.Copy()
+}
+
+// Returns an iterator over the entries of the map in insertion order.
+// The iterator does not hold any state in the map, so the view can be
+// iterated over from multiple goroutines at once.
+func (r *ReadOnlyMap[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return func(yield func(*MapEntry[K, V]) bool) {
+		for _, k := range r.inner.keys {
+			if !yield(&MapEntry[K, V]{k, r.inner.inner[k]}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package ezs_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyArray(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray([]int{1, 2, 3, 4})
+	view := arr.ReadOnly()
+
+	assert.Equal(4, view.Length())
+	assert.Equal(4, view.At(-1))
+	assert.Equal(2, view.FindIndex(func(v, _ int) bool { return v == 3 }))
+	assert.True(view.Some(func(v, _ int) bool { return v == 4 }))
+	assert.True(view.Every(func(v, _ int) bool { return v > 0 }))
+	assert.Equal(
+		[]int{2, 4},
+		view.Filter(func(v, _ int) bool { return v%2 == 0 }).ToSlice(),
+	)
+
+	arr.Push(5)
+	assert.Equal(5, view.Length())
+
+	iteratedOver := []int{}
+	for v := range view.Iter() {
+		iteratedOver = append(iteratedOver, v)
+	}
+	assert.Equal([]int{1, 2, 3, 4, 5}, iteratedOver)
+}
+
+func TestReadOnlyArraySliceAndCopy(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray(make([]int, 0, 10)).Push(1, 2, 3, 4)
+	view := arr.ReadOnly()
+
+	slice := view.Slice(1, 3)
+	assert.Equal([]int{2, 3}, slice.ToSlice())
+
+	copied := slice.Copy()
+	copied.Push(100)
+	assert.Equal([]int{1, 2, 3, 4}, arr.ToSlice())
+
+	mutable := view.Copy()
+	mutable.Set(0, 100)
+	assert.Equal(1, arr.At(0))
+}
+
+func TestReadOnlyArraySliceConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	arr := NewArray([]int{1, 2, 3, 4})
+	view := arr.ReadOnly()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				view.Slice(1, 3).Slice(0, 1).At(0)
+			}
+		}()
+	}
+	wg.Wait()
+
+	slice := view.Slice(1, 3)
+	arr.Set(1, 20)
+	assert.Equal([]int{20, 3}, slice.ToSlice())
+}
+
+func TestReadOnlyMap(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMap(map[string]int{})
+	m.Set("one", 1).Set("two", 2)
+	view := m.ReadOnly()
+
+	assert.True(view.Has("one"))
+	v, ok := view.Get("two")
+	assert.True(ok)
+	assert.Equal(2, v)
+	assert.Equal([]string{"one", "two"}, view.Keys().ToSlice())
+
+	k, ok := view.FindKey(func(_ string, v int) bool { return v == 2 })
+	assert.True(ok)
+	assert.Equal("two", k)
+
+	m.Set("three", 3)
+	assert.Equal(3, view.Count())
+
+	keys := []string{}
+	for e := range view.Iter() {
+		keys = append(keys, e.Key)
+	}
+	assert.Equal([]string{"one", "two", "three"}, keys)
+
+	copied := view.Copy()
+	copied.Delete("one")
+	assert.True(m.Has("one"))
+}
@@ -0,0 +1,438 @@
+package ezs
+
+import "cmp"
+
+type sortedNode[K comparable, V any] struct {
+	key    K
+	value  V
+	left   *sortedNode[K, V]
+	right  *sortedNode[K, V]
+	height int
+	size   int
+}
+
+func (n *sortedNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *sortedNode[K, V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *sortedNode[K, V]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+func (n *sortedNode[K, V]) rotateRight() *sortedNode[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *sortedNode[K, V]) rotateLeft() *sortedNode[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *sortedNode[K, V]) balance() *sortedNode[K, V] {
+	n.update()
+	switch diff := n.left.getHeight() - n.right.getHeight(); {
+	case diff > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case diff < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *sortedNode[K, V]) removeMin() (*sortedNode[K, V], *sortedNode[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var min *sortedNode[K, V]
+	n.left, min = n.left.removeMin()
+	return n.balance(), min
+}
+
+// Iterates over the nodes in order, stops when fn returns false
+func (n *sortedNode[K, V]) walk(fn func(*sortedNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.walk(fn) && fn(n) && n.right.walk(fn)
+}
+
+// Iterates over the nodes in reverse order, stops when fn returns false
+func (n *sortedNode[K, V]) walkReverse(fn func(*sortedNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.right.walkReverse(fn) && fn(n) && n.left.walkReverse(fn)
+}
+
+// Map which keeps its entries sorted by key in a balanced binary
+// search tree. Set, Get, Delete, Rank and Select are O(log n).
+type SortedMap[K comparable, V any] struct {
+	root    *sortedNode[K, V]
+	compare func(a, b K) int
+	iterIdx int
+}
+
+func NewSortedMap[K cmp.Ordered, V any](inner map[K]V) *SortedMap[K, V] {
+	return NewSortedMapFunc(inner, cmp.Compare[K])
+}
+
+// Creates a new SortedMap which orders the keys using the given
+// compare function
+func NewSortedMapFunc[K comparable, V any](inner map[K]V, compare func(a, b K) int) *SortedMap[K, V] {
+	m := &SortedMap[K, V]{compare: compare}
+	for k, v := range inner {
+		m.Set(k, v)
+	}
+	return m
+}
+
+func (m *SortedMap[K, V]) find(key K) *sortedNode[K, V] {
+	n := m.root
+	for n != nil {
+		c := m.compare(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+func (m *SortedMap[K, V]) insert(n *sortedNode[K, V], key K, value V) *sortedNode[K, V] {
+	if n == nil {
+		return &sortedNode[K, V]{key: key, value: value, height: 1, size: 1}
+	}
+	c := m.compare(key, n.key)
+	switch {
+	case c < 0:
+		n.left = m.insert(n.left, key, value)
+	case c > 0:
+		n.right = m.insert(n.right, key, value)
+	default:
+		n.value = value
+		return n
+	}
+	return n.balance()
+}
+
+func (m *SortedMap[K, V]) remove(n *sortedNode[K, V], key K) *sortedNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := m.compare(key, n.key)
+	switch {
+	case c < 0:
+		n.left = m.remove(n.left, key)
+	case c > 0:
+		n.right = m.remove(n.right, key)
+	default:
+		if n.right == nil {
+			return n.left
+		}
+		var successor *sortedNode[K, V]
+		n.right, successor = n.right.removeMin()
+		successor.left = n.left
+		successor.right = n.right
+		n = successor
+	}
+	return n.balance()
+}
+
+func (m *SortedMap[K, V]) Has(key K) bool {
+	return m.find(key) != nil
+}
+
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	if n := m.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (m *SortedMap[K, V]) Set(key K, value V) *SortedMap[K, V] {
+	m.root = m.insert(m.root, key, value)
+	return m
+}
+
+func (m *SortedMap[K, V]) Delete(key K) *SortedMap[K, V] {
+	m.root = m.remove(m.root, key)
+	return m
+}
+
+func (m *SortedMap[K, V]) Count() int {
+	return m.root.getSize()
+}
+
+// Returns the entry with the smallest key
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	return m.Select(0)
+}
+
+// Returns the entry with the largest key
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	return m.Select(m.Count() - 1)
+}
+
+// Returns the entry with the largest key less than or equal to the
+// given key
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	var found *sortedNode[K, V]
+	n := m.root
+	for n != nil {
+		c := m.compare(key, n.key)
+		if c == 0 {
+			found = n
+			break
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			found = n
+			n = n.right
+		}
+	}
+	return nodeEntry(found)
+}
+
+// Returns the entry with the smallest key greater than or equal to the
+// given key
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	var found *sortedNode[K, V]
+	n := m.root
+	for n != nil {
+		c := m.compare(key, n.key)
+		if c == 0 {
+			found = n
+			break
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			found = n
+			n = n.left
+		}
+	}
+	return nodeEntry(found)
+}
+
+func nodeEntry[K comparable, V any](n *sortedNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return n.key, n.value, true
+}
+
+// Returns the number of keys smaller than the given key, which is
+// also the position of the key if it is in the map
+func (m *SortedMap[K, V]) Rank(key K) int {
+	rank := 0
+	n := m.root
+	for n != nil {
+		c := m.compare(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += n.left.getSize() + 1
+			n = n.right
+		default:
+			return rank + n.left.getSize()
+		}
+	}
+	return rank
+}
+
+// Returns the entry at the given position in the key order
+func (m *SortedMap[K, V]) Select(idx int) (K, V, bool) {
+	if idx < 0 || idx >= m.Count() {
+		return nodeEntry[K, V](nil)
+	}
+	n := m.root
+	for {
+		leftSize := n.left.getSize()
+		switch {
+		case idx < leftSize:
+			n = n.left
+		case idx > leftSize:
+			idx -= leftSize + 1
+			n = n.right
+		default:
+			return nodeEntry(n)
+		}
+	}
+}
+
+// Returns an iterator over the entries with keys in the range
+// [lo, hi), in ascending order
+func (m *SortedMap[K, V]) Range(lo, hi K) func(func(*MapEntry[K, V]) bool) {
+	return func(yield func(*MapEntry[K, V]) bool) {
+		var walk func(n *sortedNode[K, V]) bool
+		walk = func(n *sortedNode[K, V]) bool {
+			if n == nil {
+				return true
+			}
+			aboveLo := m.compare(n.key, lo) >= 0
+			belowHi := m.compare(n.key, hi) < 0
+			if aboveLo && !walk(n.left) {
+				return false
+			}
+			if aboveLo && belowHi && !yield(&MapEntry[K, V]{n.key, n.value}) {
+				return false
+			}
+			if belowHi {
+				return walk(n.right)
+			}
+			return true
+		}
+		walk(m.root)
+	}
+}
+
+// Returns the keys in ascending order
+func (m *SortedMap[K, V]) Keys() *Array[K] {
+	keys := make([]K, 0, m.Count())
+	m.root.walk(func(n *sortedNode[K, V]) bool {
+		keys = append(keys, n.key)
+		return true
+	})
+	return NewArray(keys)
+}
+
+// Returns the values in ascending order of their keys
+func (m *SortedMap[K, V]) Values() *Array[V] {
+	values := make([]V, 0, m.Count())
+	m.root.walk(func(n *sortedNode[K, V]) bool {
+		values = append(values, n.value)
+		return true
+	})
+	return NewArray(values)
+}
+
+// Returns the entries in ascending order of the keys
+func (m *SortedMap[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	entries := make([]*MapEntry[K, V], 0, m.Count())
+	m.root.walk(func(n *sortedNode[K, V]) bool {
+		entries = append(entries, &MapEntry[K, V]{n.key, n.value})
+		return true
+	})
+	return NewArray(entries)
+}
+
+func (m *SortedMap[K, V]) ForEach(fn func(key K, value V)) {
+	m.root.walk(func(n *sortedNode[K, V]) bool {
+		fn(n.key, n.value)
+		return true
+	})
+}
+
+func (m *SortedMap[K, V]) ToMap() map[K]V {
+	newMap := make(map[K]V, m.Count())
+	m.ForEach(func(k K, v V) {
+		newMap[k] = v
+	})
+	return newMap
+}
+
+func (m *SortedMap[K, V]) Find(fn func(key K, value V) bool) (V, bool) {
+	_, v, ok := m.findEntry(fn)
+	return v, ok
+}
+
+func (m *SortedMap[K, V]) FindKey(fn func(key K, value V) bool) (K, bool) {
+	k, _, ok := m.findEntry(fn)
+	return k, ok
+}
+
+func (m *SortedMap[K, V]) findEntry(fn func(key K, value V) bool) (K, V, bool) {
+	var found *sortedNode[K, V]
+	m.root.walk(func(n *sortedNode[K, V]) bool {
+		if fn(n.key, n.value) {
+			found = n
+			return false
+		}
+		return true
+	})
+	return nodeEntry(found)
+}
+
+func (m *SortedMap[K, V]) Copy() *SortedMap[K, V] {
+	return &SortedMap[K, V]{root: m.root.copy(func(v V) V { return v }), compare: m.compare}
+}
+
+func (m *SortedMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*SortedMap[K, V])
+	m.compare = from.compare
+	m.root = from.root.copy(func(v V) V { return cloneValue(s, v) })
+}
+
+// Copies the subtree of the node, converting the values with the
+// given function
+func (n *sortedNode[K, V]) copy(value func(V) V) *sortedNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.value = value(n.value)
+	c.left = n.left.copy(value)
+	c.right = n.right.copy(value)
+	return &c
+}
+
+func (m *SortedMap[K, V]) Next() (*MapEntry[K, V], bool) {
+	if k, v, ok := m.Select(m.iterIdx); ok {
+		m.iterIdx++
+		return &MapEntry[K, V]{k, v}, false
+	}
+	return nil, true
+}
+
+func (m *SortedMap[K, V]) IterReset() {
+	m.iterIdx = 0
+}
+
+// Returns an iterator over the entries in ascending order of the keys
+func (m *SortedMap[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return Iterator(m)
+}
+
+// Returns an iterator over the entries in descending order of the keys
+func (m *SortedMap[K, V]) IterDescending() func(func(*MapEntry[K, V]) bool) {
+	return func(yield func(*MapEntry[K, V]) bool) {
+		m.root.walkReverse(func(n *sortedNode[K, V]) bool {
+			return yield(&MapEntry[K, V]{n.key, n.value})
+		})
+	}
+}
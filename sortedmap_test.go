@@ -0,0 +1,206 @@
+package ezs_test
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMapSetAndGet(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[int]string{
+		5: "five",
+		1: "one",
+	})
+	m.Set(3, "three").Set(4, "four").Set(2, "two").Set(3, "THREE")
+
+	assert.Equal(5, m.Count())
+	assert.Equal([]int{1, 2, 3, 4, 5}, m.Keys().ToSlice())
+	assert.Equal(
+		[]string{"one", "two", "THREE", "four", "five"},
+		m.Values().ToSlice(),
+	)
+
+	v, ok := m.Get(3)
+	assert.True(ok)
+	assert.Equal("THREE", v)
+	assert.False(m.Has(6))
+}
+
+func TestSortedMapDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[int]int{})
+	keys := rand.New(rand.NewSource(1)).Perm(200)
+	for _, k := range keys {
+		m.Set(k, k*10)
+	}
+	for _, k := range keys[:100] {
+		m.Delete(k)
+	}
+	m.Delete(1000)
+
+	expected := slices.Clone(keys[100:])
+	slices.Sort(expected)
+
+	assert.Equal(100, m.Count())
+	assert.Equal(expected, m.Keys().ToSlice())
+	for _, k := range expected {
+		v, ok := m.Get(k)
+		assert.True(ok)
+		assert.Equal(k*10, v)
+	}
+}
+
+func TestSortedMapFloorAndCeiling(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[int]string{10: "a", 20: "b", 30: "c"})
+
+	k, v, ok := m.Floor(25)
+	assert.True(ok)
+	assert.Equal(20, k)
+	assert.Equal("b", v)
+
+	k, _, ok = m.Floor(20)
+	assert.True(ok)
+	assert.Equal(20, k)
+
+	_, _, ok = m.Floor(5)
+	assert.False(ok)
+
+	k, v, ok = m.Ceiling(25)
+	assert.True(ok)
+	assert.Equal(30, k)
+	assert.Equal("c", v)
+
+	_, _, ok = m.Ceiling(31)
+	assert.False(ok)
+}
+
+func TestSortedMapMinAndMax(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[string]int{})
+	_, _, ok := m.Min()
+	assert.False(ok)
+
+	m.Set("b", 2).Set("c", 3).Set("a", 1)
+
+	k, v, ok := m.Min()
+	assert.True(ok)
+	assert.Equal("a", k)
+	assert.Equal(1, v)
+
+	k, v, ok = m.Max()
+	assert.True(ok)
+	assert.Equal("c", k)
+	assert.Equal(3, v)
+}
+
+func TestSortedMapRankAndSelect(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[int]bool{10: true, 20: true, 30: true, 40: true})
+
+	assert.Equal(0, m.Rank(5))
+	assert.Equal(0, m.Rank(10))
+	assert.Equal(2, m.Rank(25))
+	assert.Equal(2, m.Rank(30))
+	assert.Equal(4, m.Rank(50))
+
+	k, _, ok := m.Select(2)
+	assert.True(ok)
+	assert.Equal(30, k)
+
+	_, _, ok = m.Select(4)
+	assert.False(ok)
+	_, _, ok = m.Select(-1)
+	assert.False(ok)
+}
+
+func TestSortedMapRange(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[int]int{})
+	for i := 0; i < 20; i++ {
+		m.Set(i*5, i)
+	}
+
+	keys := []int{}
+	for e := range m.Range(12, 35) {
+		keys = append(keys, e.Key)
+	}
+	assert.Equal([]int{15, 20, 25, 30}, keys)
+
+	keys = []int{}
+	for e := range m.Range(15, 100) {
+		keys = append(keys, e.Key)
+		if e.Key == 25 {
+			break
+		}
+	}
+	assert.Equal([]int{15, 20, 25}, keys)
+}
+
+func TestSortedMapIterators(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[string]int{"b": 2, "a": 1, "c": 3})
+
+	ascending := []string{}
+	for e := range m.Iter() {
+		ascending = append(ascending, e.Key)
+	}
+
+	descending := []string{}
+	for e := range m.IterDescending() {
+		descending = append(descending, e.Key)
+	}
+
+	assert.Equal([]string{"a", "b", "c"}, ascending)
+	assert.Equal([]string{"c", "b", "a"}, descending)
+}
+
+func TestSortedMapFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMapFunc(map[string]int{
+		"Banana": 1,
+		"apple":  2,
+		"cherry": 3,
+	}, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	assert.Equal(
+		[]string{"apple", "Banana", "cherry"},
+		m.Keys().ToSlice(),
+	)
+	assert.True(m.Has("BANANA"))
+}
+
+func TestSortedMapCopyAndFind(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewSortedMap(map[int]int{1: 10, 2: 20, 3: 30})
+	c := m.Copy()
+	c.Set(4, 40).Delete(1)
+
+	assert.Equal([]int{1, 2, 3}, m.Keys().ToSlice())
+	assert.Equal([]int{2, 3, 4}, c.Keys().ToSlice())
+	assert.Equal(map[int]int{2: 20, 3: 30, 4: 40}, c.ToMap())
+
+	v, ok := m.Find(func(k, v int) bool { return v > 15 })
+	assert.True(ok)
+	assert.Equal(20, v)
+
+	k, ok := m.FindKey(func(k, v int) bool { return v > 25 })
+	assert.True(ok)
+	assert.Equal(3, k)
+}
@@ -0,0 +1,399 @@
+package ezs
+
+type TreeNode[T any] struct {
+	Value    T
+	parent   *TreeNode[T]
+	children []*TreeNode[T]
+	// Tree the node is a root of
+	tree *Tree[T]
+}
+
+// Element of a flattened tree
+type TreeEntry[T any] struct {
+	Depth int
+	Value T
+}
+
+func NewTreeNode[T any](value T) *TreeNode[T] {
+	return &TreeNode[T]{Value: value}
+}
+
+// Creates a new node with the given value and adds it as the last
+// child of this node
+func (n *TreeNode[T]) AddChild(value T) *TreeNode[T] {
+	child := NewTreeNode(value)
+	child.parent = n
+	n.children = append(n.children, child)
+	return child
+}
+
+// Adds the node as the last child of this node, detaching it from its
+// previous parent
+func (n *TreeNode[T]) AddChildNode(child *TreeNode[T]) *TreeNode[T] {
+	child.Detach()
+	child.parent = n
+	n.children = append(n.children, child)
+	return child
+}
+
+// Removes the node from its parent, or from the roots of its Tree,
+// making it the root of its own subtree
+func (n *TreeNode[T]) Detach() *TreeNode[T] {
+	if n.tree != nil {
+		n.tree.roots = removeNode(n.tree.roots, n)
+		n.tree = nil
+	}
+	if n.parent == nil {
+		return n
+	}
+	n.parent.children = removeNode(n.parent.children, n)
+	n.parent = nil
+	return n
+}
+
+func removeNode[T any](nodes []*TreeNode[T], n *TreeNode[T]) []*TreeNode[T] {
+	for i, c := range nodes {
+		if c == n {
+			return append(nodes[:i:i], nodes[i+1:]...)
+		}
+	}
+	return nodes
+}
+
+// Copies the node along with its parent, children and tree, so the
+// copy is part of a copy of the whole hierarchy
+func (n *TreeNode[T]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*TreeNode[T])
+	n.Value = cloneValue(s, from.Value)
+	n.parent = cloneValue(s, from.parent)
+	n.tree = cloneValue(s, from.tree)
+	n.children = make([]*TreeNode[T], len(from.children))
+	for i, c := range from.children {
+		n.children[i] = cloneValue(s, c)
+	}
+}
+
+// Returns the parent of the node or nil if the node is a root
+func (n *TreeNode[T]) Parent() *TreeNode[T] {
+	return n.parent
+}
+
+func (n *TreeNode[T]) Children() *Array[*TreeNode[T]] {
+	children := make([]*TreeNode[T], len(n.children))
+	copy(children, n.children)
+	return NewArray(children)
+}
+
+func (n *TreeNode[T]) IsRoot() bool {
+	return n.parent == nil
+}
+
+func (n *TreeNode[T]) IsLeaf() bool {
+	return len(n.children) == 0
+}
+
+// Returns the number of ancestors of the node, root nodes have depth 0
+func (n *TreeNode[T]) Depth() int {
+	depth := 0
+	for p := n.parent; p != nil; p = p.parent {
+		depth++
+	}
+	return depth
+}
+
+// Returns the nodes from the root to this node, inclusive
+func (n *TreeNode[T]) Path() *Array[*TreeNode[T]] {
+	path := []*TreeNode[T]{}
+	for p := n; p != nil; p = p.parent {
+		path = append(path, p)
+	}
+	return NewArray(path).Reverse()
+}
+
+// Returns the number of nodes in the subtree of this node, including
+// the node itself
+func (n *TreeNode[T]) Count() int {
+	count := 1
+	for _, c := range n.children {
+		count += c.Count()
+	}
+	return count
+}
+
+func (n *TreeNode[T]) preOrder(yield func(*TreeNode[T]) bool) bool {
+	if !yield(n) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.preOrder(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *TreeNode[T]) postOrder(yield func(*TreeNode[T]) bool) bool {
+	for _, c := range n.children {
+		if !c.postOrder(yield) {
+			return false
+		}
+	}
+	return yield(n)
+}
+
+func levelOrder[T any](roots []*TreeNode[T], yield func(*TreeNode[T]) bool) {
+	queue := append([]*TreeNode[T]{}, roots...)
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if !yield(node) {
+			return
+		}
+		queue = append(queue, node.children...)
+	}
+}
+
+// Returns an iterator visiting the node and then each subtree of its
+// children
+func (n *TreeNode[T]) PreOrder() func(func(*TreeNode[T]) bool) {
+	return func(yield func(*TreeNode[T]) bool) {
+		n.preOrder(yield)
+	}
+}
+
+// Returns an iterator visiting each subtree of the node's children and
+// then the node itself
+func (n *TreeNode[T]) PostOrder() func(func(*TreeNode[T]) bool) {
+	return func(yield func(*TreeNode[T]) bool) {
+		n.postOrder(yield)
+	}
+}
+
+// Returns an iterator visiting the nodes of the subtree level by level
+func (n *TreeNode[T]) LevelOrder() func(func(*TreeNode[T]) bool) {
+	return func(yield func(*TreeNode[T]) bool) {
+		levelOrder([]*TreeNode[T]{n}, yield)
+	}
+}
+
+// Returns the first node of the subtree, in pre-order, that satisfies
+// the predicate
+func (n *TreeNode[T]) Find(predicate func(*TreeNode[T]) bool) (*TreeNode[T], bool) {
+	for node := range n.PreOrder() {
+		if predicate(node) {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// Creates a copy of the subtree without the nodes that do not satisfy
+// the predicate, along with all their descendants. Returns nil if this
+// node does not satisfy the predicate.
+func (n *TreeNode[T]) Filter(predicate func(T) bool) *TreeNode[T] {
+	if !predicate(n.Value) {
+		return nil
+	}
+	clone := NewTreeNode(n.Value)
+	for _, c := range n.children {
+		if filtered := c.Filter(predicate); filtered != nil {
+			clone.AddChildNode(filtered)
+		}
+	}
+	return clone
+}
+
+func (n *TreeNode[T]) flatten(depth int, entries []*TreeEntry[T]) []*TreeEntry[T] {
+	entries = append(entries, &TreeEntry[T]{depth, n.Value})
+	for _, c := range n.children {
+		entries = c.flatten(depth+1, entries)
+	}
+	return entries
+}
+
+// Returns the values of the subtree in pre-order along with their
+// depth relative to this node
+func (n *TreeNode[T]) Flatten() *Array[*TreeEntry[T]] {
+	return NewArray(n.flatten(0, nil))
+}
+
+// Creates a copy of the subtree with the values converted by the
+// mapper function
+func MapTreeNode[T any, U any](node *TreeNode[T], mapper func(T) U) *TreeNode[U] {
+	clone := NewTreeNode(mapper(node.Value))
+	for _, c := range node.children {
+		clone.AddChildNode(MapTreeNode(c, mapper))
+	}
+	return clone
+}
+
+// Collection of root nodes, each with its own hierarchy of children
+type Tree[T any] struct {
+	roots []*TreeNode[T]
+}
+
+func NewTree[T any](roots ...*TreeNode[T]) *Tree[T] {
+	t := &Tree[T]{}
+	for _, r := range roots {
+		t.AddRootNode(r)
+	}
+	return t
+}
+
+func (t *Tree[T]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*Tree[T])
+	t.roots = make([]*TreeNode[T], len(from.roots))
+	for i, r := range from.roots {
+		t.roots[i] = cloneValue(s, r)
+	}
+}
+
+// Creates a new root node with the given value
+func (t *Tree[T]) AddRoot(value T) *TreeNode[T] {
+	return t.appendRoot(NewTreeNode(value))
+}
+
+// Adds the node as the last root of the tree, detaching it from its
+// parent or previous tree. Does nothing if the node already is a root
+// of the tree.
+func (t *Tree[T]) AddRootNode(root *TreeNode[T]) *TreeNode[T] {
+	if root.tree == t {
+		return root
+	}
+	return t.appendRoot(root.Detach())
+}
+
+func (t *Tree[T]) appendRoot(root *TreeNode[T]) *TreeNode[T] {
+	root.tree = t
+	t.roots = append(t.roots, root)
+	return root
+}
+
+func (t *Tree[T]) Roots() *Array[*TreeNode[T]] {
+	roots := make([]*TreeNode[T], len(t.roots))
+	copy(roots, t.roots)
+	return NewArray(roots)
+}
+
+// Returns the number of nodes in the tree
+func (t *Tree[T]) Count() int {
+	count := 0
+	for _, r := range t.roots {
+		count += r.Count()
+	}
+	return count
+}
+
+// Returns an iterator visiting each root and its subtree in pre-order
+func (t *Tree[T]) PreOrder() func(func(*TreeNode[T]) bool) {
+	return func(yield func(*TreeNode[T]) bool) {
+		for _, r := range t.roots {
+			if !r.preOrder(yield) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator visiting each root and its subtree in post-order
+func (t *Tree[T]) PostOrder() func(func(*TreeNode[T]) bool) {
+	return func(yield func(*TreeNode[T]) bool) {
+		for _, r := range t.roots {
+			if !r.postOrder(yield) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator visiting the nodes level by level, starting with
+// all the roots
+func (t *Tree[T]) LevelOrder() func(func(*TreeNode[T]) bool) {
+	return func(yield func(*TreeNode[T]) bool) {
+		levelOrder(t.roots, yield)
+	}
+}
+
+// Returns the first node, in pre-order, that satisfies the predicate
+func (t *Tree[T]) Find(predicate func(*TreeNode[T]) bool) (*TreeNode[T], bool) {
+	for node := range t.PreOrder() {
+		if predicate(node) {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// Creates a copy of the tree without the nodes that do not satisfy
+// the predicate, along with all their descendants
+func (t *Tree[T]) Filter(predicate func(T) bool) *Tree[T] {
+	filtered := NewTree[T]()
+	for _, r := range t.roots {
+		if f := r.Filter(predicate); f != nil {
+			filtered.appendRoot(f)
+		}
+	}
+	return filtered
+}
+
+// Returns the values of the tree in pre-order along with their depth
+func (t *Tree[T]) Flatten() *Array[*TreeEntry[T]] {
+	var entries []*TreeEntry[T]
+	for _, r := range t.roots {
+		entries = r.flatten(0, entries)
+	}
+	return NewArray(entries)
+}
+
+// Creates a copy of the tree with the values converted by the mapper
+// function
+func MapTree[T any, U any](tree *Tree[T], mapper func(T) U) *Tree[U] {
+	mapped := NewTree[U]()
+	for _, r := range tree.roots {
+		mapped.appendRoot(MapTreeNode(r, mapper))
+	}
+	return mapped
+}
+
+// Builds a tree from a flat list of records. The {getParentID}
+// function returns the ID of the record's parent, or false if the
+// record has no parent. Records which parent is not in the array, or
+// which would create a cycle, become roots. The order of the children
+// follows the order of the records in the array.
+func BuildTree[T any, I comparable](
+	array *Array[T],
+	getID func(T) I,
+	getParentID func(T) (I, bool),
+) *Tree[T] {
+	nodes := make([]*TreeNode[T], len(array.data))
+	byID := make(map[I]*TreeNode[T], len(array.data))
+	for idx, v := range array.data {
+		nodes[idx] = NewTreeNode(v)
+		if _, exists := byID[getID(v)]; !exists {
+			byID[getID(v)] = nodes[idx]
+		}
+	}
+
+	tree := NewTree[T]()
+	for idx, v := range array.data {
+		node := nodes[idx]
+		parentID, hasParent := getParentID(v)
+		parent, ok := byID[parentID]
+		if !hasParent || !ok || isAncestorOrSelf(node, parent) {
+			tree.appendRoot(node)
+			continue
+		}
+		node.parent = parent
+		parent.children = append(parent.children, node)
+	}
+	return tree
+}
+
+func isAncestorOrSelf[T any](ancestor, node *TreeNode[T]) bool {
+	for p := node; p != nil; p = p.parent {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
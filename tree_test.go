@@ -0,0 +1,230 @@
+package ezs_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+// Builds a tree with the following structure:
+//
+//	root
+//	├── a
+//	│   ├── a1
+//	│   └── a2
+//	└── b
+//	    └── b1
+func buildTestTree() *Tree[string] {
+	tree := NewTree[string]()
+	root := tree.AddRoot("root")
+	a := root.AddChild("a")
+	a.AddChild("a1")
+	a.AddChild("a2")
+	root.AddChild("b").AddChild("b1")
+	return tree
+}
+
+func collectTreeValues(iter func(func(*TreeNode[string]) bool)) []string {
+	values := []string{}
+	for n := range iter {
+		values = append(values, n.Value)
+	}
+	return values
+}
+
+func TestTreeNodeRelations(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := buildTestTree()
+	root := tree.Roots().At(0)
+	a2, ok := tree.Find(func(n *TreeNode[string]) bool { return n.Value == "a2" })
+
+	assert.True(ok)
+	assert.True(root.IsRoot())
+	assert.False(root.IsLeaf())
+	assert.True(a2.IsLeaf())
+	assert.Equal("a", a2.Parent().Value)
+	assert.Equal(2, a2.Depth())
+	assert.Equal(0, root.Depth())
+	assert.Equal(
+		[]string{"root", "a", "a2"},
+		MapTo(a2.Path(), func(n *TreeNode[string]) string { return n.Value }).ToSlice(),
+	)
+	assert.Equal(6, tree.Count())
+	assert.Equal(3, a2.Parent().Count())
+}
+
+func TestTreeIterators(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := buildTestTree()
+
+	assert.Equal(
+		[]string{"root", "a", "a1", "a2", "b", "b1"},
+		collectTreeValues(tree.PreOrder()),
+	)
+	assert.Equal(
+		[]string{"a1", "a2", "a", "b1", "b", "root"},
+		collectTreeValues(tree.PostOrder()),
+	)
+	assert.Equal(
+		[]string{"root", "a", "b", "a1", "a2", "b1"},
+		collectTreeValues(tree.LevelOrder()),
+	)
+
+	a := tree.Roots().At(0).Children().At(0)
+	assert.Equal([]string{"a", "a1", "a2"}, collectTreeValues(a.PreOrder()))
+
+	partial := []string{}
+	for n := range tree.PreOrder() {
+		partial = append(partial, n.Value)
+		if n.Value == "a1" {
+			break
+		}
+	}
+	assert.Equal([]string{"root", "a", "a1"}, partial)
+}
+
+func TestTreeNodeMoving(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := buildTestTree()
+	root := tree.Roots().At(0)
+	b, _ := tree.Find(func(n *TreeNode[string]) bool { return n.Value == "b" })
+	a1, _ := tree.Find(func(n *TreeNode[string]) bool { return n.Value == "a1" })
+
+	b.AddChildNode(a1)
+	assert.Equal(
+		[]string{"root", "a", "a2", "b", "b1", "a1"},
+		collectTreeValues(tree.PreOrder()),
+	)
+
+	b.Detach()
+	assert.Nil(b.Parent())
+	assert.Equal(1, root.Children().Length())
+	assert.Equal(3, tree.Count())
+}
+
+func TestTreeMovingRoots(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewTree[string]()
+	a := tree.AddRoot("a")
+	b := tree.AddRoot("b")
+
+	a.AddChildNode(b)
+	assert.Equal(2, tree.Count())
+	assert.Equal([]string{"a", "b"}, collectTreeValues(tree.PreOrder()))
+	assert.Equal(1, tree.Roots().Length())
+
+	tree.AddRootNode(a)
+	assert.Equal(1, tree.Roots().Length())
+
+	tree.AddRootNode(b)
+	assert.Equal([]*TreeNode[string]{a, b}, tree.Roots().ToSlice())
+	assert.Nil(b.Parent())
+
+	other := NewTree(b)
+	assert.Equal([]*TreeNode[string]{a}, tree.Roots().ToSlice())
+	assert.Equal(1, other.Count())
+
+	b.Detach()
+	assert.Equal(0, other.Roots().Length())
+}
+
+func TestTreeFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := buildTestTree()
+	filtered := tree.Filter(func(v string) bool {
+		return !strings.HasPrefix(v, "a")
+	})
+
+	assert.Equal(
+		[]string{"root", "b", "b1"},
+		collectTreeValues(filtered.PreOrder()),
+	)
+	assert.Equal(6, tree.Count())
+
+	assert.Equal(0, tree.Filter(func(v string) bool { return v != "root" }).Count())
+}
+
+func TestMapTree(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := buildTestTree()
+	mapped := MapTree(tree, func(v string) int { return len(v) })
+
+	values := []int{}
+	for n := range mapped.PreOrder() {
+		values = append(values, n.Value)
+	}
+
+	assert.Equal([]int{4, 1, 2, 2, 1, 2}, values)
+}
+
+func TestTreeFlatten(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := buildTestTree()
+
+	assert.Equal(
+		[]*TreeEntry[string]{
+			{0, "root"},
+			{1, "a"},
+			{2, "a1"},
+			{2, "a2"},
+			{1, "b"},
+			{2, "b1"},
+		},
+		tree.Flatten().ToSlice(),
+	)
+}
+
+type category struct {
+	ID       int
+	ParentID int
+	Name     string
+}
+
+func TestBuildTree(t *testing.T) {
+	assert := assert.New(t)
+
+	records := NewArray([]category{
+		{4, 2, "phones"},
+		{1, 0, "home"},
+		{2, 0, "electronics"},
+		{3, 1, "kitchen"},
+		{5, 2, "laptops"},
+		{6, 42, "orphan"},
+		{7, 8, "cycle-a"},
+		{8, 7, "cycle-b"},
+	})
+
+	tree := BuildTree(
+		records,
+		func(c category) int { return c.ID },
+		func(c category) (int, bool) { return c.ParentID, c.ParentID != 0 },
+	)
+
+	flat := MapTo(tree.Flatten(), func(e *TreeEntry[category]) string {
+		return strings.Repeat("-", e.Depth) + e.Value.Name
+	})
+
+	assert.Equal(
+		[]string{
+			"home",
+			"-kitchen",
+			"electronics",
+			"-phones",
+			"-laptops",
+			"orphan",
+			"cycle-b",
+			"-cycle-a",
+		},
+		flat.ToSlice(),
+	)
+	assert.Equal(8, tree.Count())
+}
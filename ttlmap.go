@@ -0,0 +1,303 @@
+package ezs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Source of the current time, can be replaced to control the passage
+// of time in tests
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Clock reading the time from the operating system
+var SystemClock Clock = systemClock{}
+
+type ttlEntry[V any] struct {
+	value     V
+	ttl       time.Duration
+	expiresAt time.Time
+}
+
+func (e *ttlEntry[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// Map which entries expire after a given time to live. Expired entries
+// are removed lazily when accessed, by calling EvictExpired, or
+// periodically by a janitor goroutine started with StartJanitor.
+// It is safe to use from multiple goroutines.
+type TTLMap[K comparable, V any] struct {
+	entries     *Map[K, *ttlEntry[V]]
+	defaultTTL  time.Duration
+	clock       Clock
+	onExpire    func(key K, value V)
+	mu          sync.Mutex
+	stopJanitor context.CancelFunc
+	janitorDone chan struct{}
+}
+
+// Creates a new TTLMap where entries added with Set expire after
+// {defaultTTL}. A TTL of zero or less means the entry never expires.
+func NewTTLMap[K comparable, V any](defaultTTL time.Duration) *TTLMap[K, V] {
+	return &TTLMap[K, V]{
+		entries:    NewMap(map[K]*ttlEntry[V]{}),
+		defaultTTL: defaultTTL,
+		clock:      SystemClock,
+	}
+}
+
+// The janitor of the original map is not started for the copy
+func (m *TTLMap[K, V]) deepCopyFrom(src any, s *cloneState) {
+	from := src.(*TTLMap[K, V])
+	from.mu.Lock()
+	defer from.mu.Unlock()
+
+	m.entries = NewMap(map[K]*ttlEntry[V]{})
+	for _, k := range from.entries.keys {
+		e := from.entries.inner[k]
+		m.entries.insert(k, &ttlEntry[V]{cloneValue(s, e.value), e.ttl, e.expiresAt})
+	}
+	m.defaultTTL = from.defaultTTL
+	m.clock = from.clock
+	m.onExpire = from.onExpire
+}
+
+// Replaces the clock used to determine the entries expiration
+func (m *TTLMap[K, V]) WithClock(clock Clock) *TTLMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = clock
+	return m
+}
+
+// Sets the callback invoked with every entry removed from the map
+// because it has expired
+func (m *TTLMap[K, V]) OnExpire(fn func(key K, value V)) *TTLMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onExpire = fn
+	return m
+}
+
+func (m *TTLMap[K, V]) newEntry(value V, ttl time.Duration) *ttlEntry[V] {
+	e := &ttlEntry[V]{value: value, ttl: ttl}
+	if ttl > 0 {
+		e.expiresAt = m.clock.Now().Add(ttl)
+	}
+	return e
+}
+
+// Returns the entry of the given key if it has not expired, expired
+// entry is removed from the map and appended to {expired}
+func (m *TTLMap[K, V]) lookup(key K, expired *[]*MapEntry[K, V]) (*ttlEntry[V], bool) {
+	e, ok := m.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if e.expired(m.clock.Now()) {
+		m.entries.Delete(key)
+		*expired = append(*expired, &MapEntry[K, V]{key, e.value})
+		return nil, false
+	}
+	return e, true
+}
+
+func (m *TTLMap[K, V]) sweep() []*MapEntry[K, V] {
+	var expired []*MapEntry[K, V]
+	now := m.clock.Now()
+	m.entries.deleteFunc(func(k K, e *ttlEntry[V]) bool {
+		if !e.expired(now) {
+			return false
+		}
+		expired = append(expired, &MapEntry[K, V]{k, e.value})
+		return true
+	})
+	return expired
+}
+
+// Releases the lock and calls the expiration callback for each of
+// the expired entries
+func (m *TTLMap[K, V]) unlockAndNotify(expired []*MapEntry[K, V]) {
+	onExpire := m.onExpire
+	m.mu.Unlock()
+	if onExpire == nil {
+		return
+	}
+	for _, e := range expired {
+		onExpire(e.Key, e.Value)
+	}
+}
+
+// Adds or replaces the entry, it will expire after the default TTL
+func (m *TTLMap[K, V]) Set(key K, value V) *TTLMap[K, V] {
+	return m.SetWithTTL(key, value, m.defaultTTL)
+}
+
+// Adds or replaces the entry, it will expire after the given TTL. A
+// TTL of zero or less means the entry never expires.
+func (m *TTLMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) *TTLMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries.Set(key, m.newEntry(value, ttl))
+	return m
+}
+
+// Returns the value of the given key if it has not expired
+func (m *TTLMap[K, V]) Get(key K) (V, bool) {
+	v, _, ok := m.GetWithExpiry(key)
+	return v, ok
+}
+
+// Returns the value of the given key and the time it expires at. The
+// returned time is zero if the entry never expires.
+func (m *TTLMap[K, V]) GetWithExpiry(key K) (V, time.Time, bool) {
+	var expired []*MapEntry[K, V]
+	m.mu.Lock()
+	defer func() { m.unlockAndNotify(expired) }()
+
+	e, ok := m.lookup(key, &expired)
+	if !ok {
+		var zero V
+		return zero, time.Time{}, false
+	}
+	return e.value, e.expiresAt, true
+}
+
+// Returns true if the key is in the map and has not expired
+func (m *TTLMap[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Resets the expiration time of the entry using the TTL it was added
+// with. Returns false if there is no such entry.
+func (m *TTLMap[K, V]) Touch(key K) bool {
+	var expired []*MapEntry[K, V]
+	m.mu.Lock()
+	defer func() { m.unlockAndNotify(expired) }()
+
+	e, ok := m.lookup(key, &expired)
+	if !ok {
+		return false
+	}
+	m.entries.inner[key] = m.newEntry(e.value, e.ttl)
+	return true
+}
+
+// Removes the entry from the map without calling the expiration
+// callback
+func (m *TTLMap[K, V]) Delete(key K) *TTLMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries.Delete(key)
+	return m
+}
+
+// Removes all the expired entries and returns how many were removed
+func (m *TTLMap[K, V]) EvictExpired() int {
+	m.mu.Lock()
+	expired := m.sweep()
+	m.unlockAndNotify(expired)
+	return len(expired)
+}
+
+// Returns the number of entries that have not expired
+func (m *TTLMap[K, V]) Count() int {
+	m.mu.Lock()
+	expired := m.sweep()
+	count := m.entries.Count()
+	m.unlockAndNotify(expired)
+	return count
+}
+
+// Returns the keys of the entries that have not expired, in the
+// insertion order
+func (m *TTLMap[K, V]) Keys() *Array[K] {
+	m.mu.Lock()
+	expired := m.sweep()
+	keys := m.entries.Keys()
+	m.unlockAndNotify(expired)
+	return keys
+}
+
+// Returns the entries that have not expired, in the insertion order
+func (m *TTLMap[K, V]) Entries() *Array[*MapEntry[K, V]] {
+	m.mu.Lock()
+	expired := m.sweep()
+	entries := make([]*MapEntry[K, V], len(m.entries.keys))
+	for i, k := range m.entries.keys {
+		entries[i] = &MapEntry[K, V]{k, m.entries.inner[k].value}
+	}
+	m.unlockAndNotify(expired)
+	return NewArray(entries)
+}
+
+// Returns an iterator over a snapshot of the entries that have not
+// expired
+func (m *TTLMap[K, V]) Iter() func(func(*MapEntry[K, V]) bool) {
+	return m.Entries().Iter()
+}
+
+// Starts a goroutine removing the expired entries every {interval}.
+// The goroutine stops when the context is done or Close is called.
+// Does nothing if the janitor is already running. Panics if the
+// interval is not positive.
+func (m *TTLMap[K, V]) StartJanitor(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		panic(fmt.Sprintf("ezs: invalid janitor interval %v", interval))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.janitorDone != nil {
+		select {
+		case <-m.janitorDone:
+		default:
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	m.stopJanitor = cancel
+	m.janitorDone = done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.EvictExpired()
+			}
+		}
+	}()
+}
+
+// Stops the janitor goroutine and waits for it to exit
+func (m *TTLMap[K, V]) Close() {
+	m.mu.Lock()
+	stop, done := m.stopJanitor, m.janitorDone
+	m.stopJanitor = nil
+	m.janitorDone = nil
+	m.mu.Unlock()
+
+	if stop != nil {
+		stop()
+		<-done
+	}
+}
@@ -0,0 +1,222 @@
+package ezs_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/ncpa0cpl/ezs"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestTTLMapDefaultTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	m := NewTTLMap[string, int](time.Minute).WithClock(clock)
+	m.Set("one", 1)
+
+	clock.Advance(59 * time.Second)
+	v, ok := m.Get("one")
+	assert.True(ok)
+	assert.Equal(1, v)
+
+	clock.Advance(time.Second)
+	_, ok = m.Get("one")
+	assert.False(ok)
+	assert.Equal(0, m.Count())
+}
+
+func TestTTLMapSetWithTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	m := NewTTLMap[string, int](time.Minute).WithClock(clock)
+	m.SetWithTTL("short", 1, time.Second)
+	m.SetWithTTL("forever", 2, 0)
+	m.Set("default", 3)
+
+	clock.Advance(2 * time.Second)
+	assert.Equal([]string{"forever", "default"}, m.Keys().ToSlice())
+
+	clock.Advance(time.Hour)
+	assert.Equal([]string{"forever"}, m.Keys().ToSlice())
+}
+
+func TestTTLMapGetWithExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	start := clock.Now()
+	m := NewTTLMap[string, int](time.Minute).WithClock(clock)
+	m.Set("one", 1)
+	m.SetWithTTL("two", 2, 0)
+
+	v, exp, ok := m.GetWithExpiry("one")
+	assert.True(ok)
+	assert.Equal(1, v)
+	assert.Equal(start.Add(time.Minute), exp)
+
+	_, exp, ok = m.GetWithExpiry("two")
+	assert.True(ok)
+	assert.True(exp.IsZero())
+}
+
+func TestTTLMapTouch(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	m := NewTTLMap[string, int](time.Minute).WithClock(clock)
+	m.SetWithTTL("one", 1, 10*time.Second)
+
+	clock.Advance(8 * time.Second)
+	assert.True(m.Touch("one"))
+
+	clock.Advance(8 * time.Second)
+	assert.True(m.Has("one"))
+
+	clock.Advance(2 * time.Second)
+	assert.False(m.Has("one"))
+	assert.False(m.Touch("one"))
+}
+
+func TestTTLMapOnExpire(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	expired := []string{}
+	m := NewTTLMap[string, int](time.Minute).
+		WithClock(clock).
+		OnExpire(func(key string, value int) {
+			expired = append(expired, key)
+		})
+
+	m.Set("one", 1)
+	m.Set("two", 2)
+	m.SetWithTTL("three", 3, time.Hour)
+	m.Delete("two")
+
+	clock.Advance(time.Minute)
+
+	assert.Equal(1, m.EvictExpired())
+	assert.Equal(0, m.EvictExpired())
+	assert.Equal([]string{"one"}, expired)
+	assert.Equal(1, m.Count())
+}
+
+func TestTTLMapIter(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	m := NewTTLMap[string, int](0).WithClock(clock)
+	m.Set("one", 1)
+	m.SetWithTTL("two", 2, time.Second)
+	m.Set("three", 3)
+
+	clock.Advance(time.Second)
+
+	iteratedOver := []*MapEntry[string, int]{}
+	for e := range m.Iter() {
+		iteratedOver = append(iteratedOver, e)
+	}
+
+	assert.Equal(
+		[]*MapEntry[string, int]{{"one", 1}, {"three", 3}},
+		iteratedOver,
+	)
+}
+
+func TestTTLMapJanitor(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := newFakeClock()
+	expired := make(chan string, 1)
+	m := NewTTLMap[string, int](time.Minute).
+		WithClock(clock).
+		OnExpire(func(key string, value int) {
+			expired <- key
+		})
+	m.Set("one", 1)
+
+	m.StartJanitor(context.Background(), time.Millisecond)
+	defer m.Close()
+
+	clock.Advance(time.Minute)
+
+	select {
+	case key := <-expired:
+		assert.Equal("one", key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("janitor did not evict the expired entry")
+	}
+}
+
+func TestTTLMapJanitorStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := NewTTLMap[string, int](time.Minute)
+	m.StartJanitor(ctx, time.Millisecond)
+	cancel()
+
+	m.Close()
+	m.Close()
+}
+
+func TestTTLMapJanitorInvalidInterval(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewTTLMap[string, int](time.Minute)
+	assert.PanicsWithValue("ezs: invalid janitor interval 0s", func() {
+		m.StartJanitor(context.Background(), 0)
+	})
+	assert.Panics(func() {
+		m.StartJanitor(context.Background(), -time.Second)
+	})
+
+	// The map still accepts a valid janitor afterwards
+	m.StartJanitor(context.Background(), time.Millisecond)
+	m.Close()
+}
+
+func TestTTLMapSweepLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	const size = 100_000
+	clock := newFakeClock()
+	m := NewTTLMap[int, int](time.Minute).WithClock(clock)
+	for i := 0; i < size; i++ {
+		if i%2 == 0 {
+			m.Set(i, i)
+		} else {
+			m.SetWithTTL(i, i, 0)
+		}
+	}
+	clock.Advance(time.Minute)
+
+	start := time.Now()
+	assert.Equal(size/2, m.Count())
+	assert.Less(time.Since(start), time.Second)
+	assert.Equal([]int{1, 3, 5}, m.Keys().ToSlice()[:3])
+}